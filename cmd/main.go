@@ -2,16 +2,26 @@ package main
 
 import (
 	"flag"
+	"io"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
+	delacache "github.com/phillebaba/dela/pkg/cache"
 	"github.com/phillebaba/dela/pkg/controllers"
+	"github.com/phillebaba/dela/pkg/webhooks"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -29,31 +39,108 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var enableWebhooks bool
+	var dryRun bool
+	var namespaces string
+	var auditSink string
+	var fileSourceRoot string
+	var httpSourceAllowedURLs string
+	var enableAnnotations bool
+	var importAnnotations string
+	var cacheAllSecrets bool
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable admission webhooks. "+
+			"Requires serving certificates to be mounted in the controller manager.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Evaluate all Intents and Requests without writing to Secrets. "+
+			"Intended actions are recorded as events and in the status.")
+	flag.StringVar(&namespaces, "namespaces", "",
+		"Comma separated list of namespaces to restrict the controller manager to. "+
+			"Defaults to all namespaces.")
+	flag.StringVar(&auditSink, "audit-sink", "",
+		"Where to write audit records of all decisions, either stdout, a file path, or an http(s) URL. "+
+			"Disabled if empty.")
+	flag.StringVar(&fileSourceRoot, "file-source-root", "",
+		"Directory that file sources of Intents are read from, usually a mounted volume, with a directory for each namespace. "+
+			"File sources are disabled if empty.")
+	flag.StringVar(&httpSourceAllowedURLs, "http-source-allowed-urls", "",
+		"Comma separated list of URL prefixes that http sources of Intents are allowed to read from. "+
+			"HTTP sources are disabled if empty.")
+	flag.BoolVar(&enableAnnotations, "enable-annotations", false,
+		"Enable sharing of Secrets through annotations. "+
+			"Annotated Secrets are translated into Intents and Requests.")
+	flag.StringVar(&importAnnotations, "import-annotations", "",
+		"Comma separated list of replication annotations to import as Intents and Requests, either kubed or reflector. "+
+			"Disabled if empty.")
+	flag.BoolVar(&cacheAllSecrets, "cache-all-secrets", false,
+		"Cache all Secrets instead of only Secrets labeled as managed by dela. "+
+			"Always enabled together with --enable-annotations or --import-annotations, as they discover unlabeled Secrets.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	options := ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
 		Port:               9443,
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "c33f4029.phillebaba.io",
-	})
+	}
+	namespaceList := []string{}
+	if namespaces != "" {
+		namespaceList = strings.Split(namespaces, ",")
+	}
+	// Cluster scoped resources are cached across the cluster, also when restricted to a single namespace
+	newCache := cache.New
+	if len(namespaceList) > 0 {
+		newCache = delacache.MultiNamespaced(namespaceList)
+	}
+	if cacheAllSecrets || enableAnnotations || importAnnotations != "" {
+		options.NewCache = newCache
+	} else {
+		selector := labels.SelectorFromSet(labels.Set{delav1alpha1.ManagedLabel: "true"})
+		options.NewCache = delacache.SelectSecrets(selector, namespaceList, newCache)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	var sink audit.Sink
+	if auditSink != "" {
+		sink, err = audit.NewSink(auditSink)
+		if err != nil {
+			setupLog.Error(err, "unable to create audit sink")
+			os.Exit(1)
+		}
+	}
+
+	// Secrets are read by the default source of each reconciler, which falls back to the API server for uncached Secrets
+	sources := controllers.SecretSources{}
+	if fileSourceRoot != "" {
+		sources.File = &controllers.FileSecretSource{Root: fileSourceRoot}
+	}
+	if httpSourceAllowedURLs != "" {
+		sources.HTTP = &controllers.HTTPSecretSource{
+			Client:      &http.Client{Timeout: 30 * time.Second},
+			AllowedURLs: strings.Split(httpSourceAllowedURLs, ","),
+		}
+	}
+
 	if err = (&controllers.RequestReconciler{
 		Client:   mgr.GetClient(),
 		Log:      ctrl.Log.WithName("controllers").WithName("ShareRequest"),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("request-controller"),
+		DryRun:   dryRun,
+		Audit:    sink,
+		Sources:  sources,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ShareRequest")
 		os.Exit(1)
@@ -63,10 +150,55 @@ func main() {
 		Log:      ctrl.Log.WithName("controllers").WithName("ShareIntent"),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("intent-controller"),
+		DryRun:   dryRun,
+		Audit:    sink,
+		Sources:  sources,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ShareIntent")
 		os.Exit(1)
 	}
+	if err = (&controllers.IntentCatalogReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("IntentCatalog"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IntentCatalog")
+		os.Exit(1)
+	}
+	if enableAnnotations {
+		if err = (&controllers.AnnotationReconciler{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("Annotation"),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("annotation-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Annotation")
+			os.Exit(1)
+		}
+	}
+	if importAnnotations != "" {
+		if err = (&controllers.ImportReconciler{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("Import"),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("import-controller"),
+			Formats:  strings.Split(importAnnotations, ","),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Import")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		mgr.GetWebhookServer().Register("/validate-dela-phillebaba-io-v1alpha1-intent", &webhook.Admission{
+			Handler: &webhooks.IntentValidator{Client: mgr.GetClient()},
+		})
+		mgr.GetWebhookServer().Register("/validate-dela-phillebaba-io-v1alpha1-request", &webhook.Admission{
+			Handler: &webhooks.RequestValidator{Client: mgr.GetClient()},
+		})
+		mgr.GetWebhookServer().Register("/mutate-v1-pod", &webhook.Admission{
+			Handler: &webhooks.PodInjector{Client: mgr.GetClient()},
+		})
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
@@ -74,4 +206,11 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	// Wait for buffered audit records to be written
+	if closer, ok := sink.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			setupLog.Error(err, "problem closing audit sink")
+		}
+	}
 }
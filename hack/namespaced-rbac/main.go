@@ -0,0 +1,164 @@
+// Generates namespaced RBAC for running the controller restricted to a set of namespaces.
+// Rules from the generated manager ClusterRole are split into a Role per namespace,
+// while rules for cluster scoped resources are kept in a ClusterRole.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func main() {
+	var namespaces string
+	var rolePath string
+	var crdPath string
+	var name string
+	var serviceAccount string
+	var serviceAccountNamespace string
+	flag.StringVar(&namespaces, "namespaces", "", "Comma separated list of namespaces the controller is restricted to.")
+	flag.StringVar(&rolePath, "role", "config/rbac/role.yaml", "Path to the generated manager ClusterRole.")
+	flag.StringVar(&crdPath, "crds", "config/crd/bases", "Path to the directory with the generated CRDs.")
+	flag.StringVar(&name, "name", "dela-manager-role", "Name of the generated Roles and ClusterRole.")
+	flag.StringVar(&serviceAccount, "service-account", "default", "Name of the controller manager ServiceAccount.")
+	flag.StringVar(&serviceAccountNamespace, "service-account-namespace", "dela-system", "Namespace of the controller manager ServiceAccount.")
+	flag.Parse()
+
+	if namespaces == "" {
+		fmt.Fprintln(os.Stderr, "at least one namespace is required")
+		os.Exit(1)
+	}
+
+	clusterRole, err := readClusterRole(rolePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	clusterScoped, err := readClusterScopedResources(crdPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	namespacedRules, clusterRules := splitRules(clusterRole.Rules, clusterScoped)
+	subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: serviceAccount, Namespace: serviceAccountNamespace}
+	objs := []interface{}{}
+	for _, ns := range strings.Split(namespaces, ",") {
+		objs = append(objs,
+			&rbacv1.Role{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+				Rules:      namespacedRules,
+			},
+			&rbacv1.RoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: name + "binding", Namespace: ns},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+				Subjects:   []rbacv1.Subject{subject},
+			},
+		)
+	}
+	if len(clusterRules) > 0 {
+		objs = append(objs,
+			&rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Rules:      clusterRules,
+			},
+			&rbacv1.ClusterRoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: name + "binding"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+				Subjects:   []rbacv1.Subject{subject},
+			},
+		)
+	}
+
+	for _, obj := range objs {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("---\n%s", b)
+	}
+}
+
+// readClusterRole reads the ClusterRole generated by controller-gen.
+func readClusterRole(path string) (*rbacv1.ClusterRole, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	clusterRole := &rbacv1.ClusterRole{}
+	for _, doc := range strings.Split(string(b), "\n---\n") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		if err := yaml.Unmarshal([]byte(doc), clusterRole); err != nil {
+			return nil, err
+		}
+		return clusterRole, nil
+	}
+
+	return nil, fmt.Errorf("no ClusterRole found in %s", path)
+}
+
+// readClusterScopedResources returns the plural names of all cluster scoped CRDs and core resources used by the controller.
+func readClusterScopedResources(path string) (map[string]bool, error) {
+	files, err := filepath.Glob(filepath.Join(path, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	resources := map[string]bool{"namespaces": true}
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		crd := &apiextensionsv1beta1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(b, crd); err != nil {
+			return nil, err
+		}
+		if crd.Spec.Scope == apiextensionsv1beta1.ClusterScoped {
+			resources[crd.Spec.Names.Plural] = true
+		}
+	}
+
+	return resources, nil
+}
+
+// splitRules splits the rules into rules for namespaced resources and rules for cluster scoped resources.
+func splitRules(rules []rbacv1.PolicyRule, clusterScoped map[string]bool) ([]rbacv1.PolicyRule, []rbacv1.PolicyRule) {
+	namespacedRules := []rbacv1.PolicyRule{}
+	clusterRules := []rbacv1.PolicyRule{}
+	for _, rule := range rules {
+		namespacedRule := *rule.DeepCopy()
+		namespacedRule.Resources = []string{}
+		clusterRule := *rule.DeepCopy()
+		clusterRule.Resources = []string{}
+		for _, resource := range rule.Resources {
+			if clusterScoped[strings.Split(resource, "/")[0]] {
+				clusterRule.Resources = append(clusterRule.Resources, resource)
+			} else {
+				namespacedRule.Resources = append(namespacedRule.Resources, resource)
+			}
+		}
+		if len(namespacedRule.Resources) > 0 {
+			namespacedRules = append(namespacedRules, namespacedRule)
+		}
+		if len(clusterRule.Resources) > 0 {
+			clusterRules = append(clusterRules, clusterRule)
+		}
+	}
+
+	return namespacedRules, clusterRules
+}
@@ -0,0 +1,24 @@
+#!/usr/bin/env bash
+
+# Generates the clientset, informers and listers in pkg/client for the dela API.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+CODEGEN_VERSION=${CODEGEN_VERSION:-v0.17.2}
+MODULE=github.com/phillebaba/dela
+ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+TMP_DIR=$(mktemp -d)
+trap 'rm -rf "${TMP_DIR}"' EXIT
+
+git clone --quiet --depth 1 --branch "kubernetes-${CODEGEN_VERSION#v}" https://github.com/kubernetes/code-generator.git "${TMP_DIR}/code-generator"
+
+bash "${TMP_DIR}/code-generator/generate-groups.sh" client,informer,lister \
+  "${MODULE}/pkg/client" "${MODULE}/pkg" \
+  "api:v1alpha1" \
+  --output-base "${TMP_DIR}/out" \
+  --go-header-file "${ROOT}/hack/boilerplate.go.txt"
+
+rm -rf "${ROOT}/pkg/client"
+cp -r "${TMP_DIR}/out/${MODULE}/pkg/client" "${ROOT}/pkg/client"
@@ -0,0 +1,16 @@
+package v1alpha1
+
+const (
+	// ShareToAnnotation is set on a Secret to a comma separated list of namespaces, plain text or regex, that the Secret is shared to.
+	// An Intent for the Secret is created when annotation sharing is enabled.
+	ShareToAnnotation = "dela.phillebaba.io/share-to"
+	// ShareFromAnnotation is set on a Secret stub to the namespace/name of a shared Secret that the stub should contain a copy of.
+	// A Request for the stub is created when annotation sharing is enabled.
+	ShareFromAnnotation = "dela.phillebaba.io/share-from"
+	// ShareFromLabel is set on Requests created for a Secret stub with the share from annotation.
+	// The label is used instead of an owner reference, as the Request controls the stub once it is adopted.
+	ShareFromLabel = "dela.phillebaba.io/share-from"
+)
+
+// ImportedFromAnnotation is set on Intents and Requests created from the replication annotations of another tool, such as kubed or reflector.
+const ImportedFromAnnotation = "dela.phillebaba.io/imported-from"
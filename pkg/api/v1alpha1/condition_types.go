@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType represents the type of a Condition.
+type ConditionType string
+
+const (
+	// Controller is running in dry run mode and does not write to Secrets.
+	ConditionDryRun ConditionType = "DryRun"
+	// Resource is not allowed by a SharePolicy.
+	ConditionPolicyViolation ConditionType = "PolicyViolation"
+	// Shared data does not fulfill the content contract of the Intent.
+	ConditionContractViolation ConditionType = "ContractViolation"
+	// Shared certificate expires soon or has expired.
+	ConditionCertificateExpiring ConditionType = "CertificateExpiring"
+)
+
+// Condition contains details for one aspect of the current state of a resource.
+type Condition struct {
+	// Type of condition.
+	Type ConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Machine readable reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GetCondition returns the condition with the given type, or nil if it does not exist.
+func GetCondition(conditions []Condition, conditionType ConditionType) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates the condition in the list of conditions.
+// The transition time is only updated when the status of the condition changes.
+func SetCondition(conditions []Condition, condition Condition) []Condition {
+	existing := GetCondition(conditions, condition.Type)
+	if existing == nil {
+		if condition.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = metav1.Now()
+		}
+		return append(conditions, condition)
+	}
+
+	if existing.Status != condition.Status {
+		existing.Status = condition.Status
+		existing.LastTransitionTime = metav1.Now()
+	}
+	existing.Reason = condition.Reason
+	existing.Message = condition.Message
+	return conditions
+}
+
+// RemoveCondition removes the condition with the given type from the list of conditions.
+func RemoveCondition(conditions []Condition, conditionType ConditionType) []Condition {
+	if GetCondition(conditions, conditionType) == nil {
+		return conditions
+	}
+
+	result := []Condition{}
+	for _, condition := range conditions {
+		if condition.Type != conditionType {
+			result = append(result, condition)
+		}
+	}
+	return result
+}
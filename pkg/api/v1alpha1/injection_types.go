@@ -0,0 +1,26 @@
+package v1alpha1
+
+// InjectionMode describes how a shared Secret is injected into the containers of a Pod.
+type InjectionMode string
+
+const (
+	// Secret is mounted as a volume.
+	InjectionModeVolume InjectionMode = "volume"
+	// Secret keys are exposed as environment variables.
+	InjectionModeEnvFrom InjectionMode = "envFrom"
+)
+
+const (
+	// InjectLabel is set to true on a Pod to opt in to injection, only labeled Pods are sent to the Pod webhook.
+	InjectLabel = "dela.phillebaba.io/inject"
+	// InjectionNamespaceLabel is set to disabled on a Namespace to exclude its Pods from the Pod webhook.
+	InjectionNamespaceLabel = "dela.phillebaba.io/injection"
+	// InjectIntentAnnotation is set on a Pod to the namespace/name of an Intent to inject the shared Secret into the Pod.
+	InjectIntentAnnotation = "dela.phillebaba.io/inject-intent"
+	// InjectModeAnnotation is set on a Pod to the InjectionMode, defaults to volume.
+	InjectModeAnnotation = "dela.phillebaba.io/inject-mode"
+	// InjectContainersAnnotation is set on a Pod to a comma separated list of containers to inject into, defaults to all containers.
+	InjectContainersAnnotation = "dela.phillebaba.io/inject-containers"
+	// InjectMountPathAnnotation is set on a Pod to the path the volume is mounted at, defaults to /var/run/secrets/dela/<intent name>.
+	InjectMountPathAnnotation = "dela.phillebaba.io/inject-mount-path"
+)
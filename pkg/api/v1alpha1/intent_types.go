@@ -1,17 +1,176 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Visibility describes if an Intent is listed in the IntentCatalog.
+// +kubebuilder:validation:Enum=Public;Private
+type Visibility string
+
+const (
+	// Intent is listed in the IntentCatalog of whitelisted namespaces.
+	VisibilityPublic Visibility = "Public"
+	// Intent is not listed in any IntentCatalog.
+	VisibilityPrivate Visibility = "Private"
+)
+
+const (
+	// IntentProtectionFinalizer is set on Intents with deletion protection, and blocks deletion while Ready Requests reference the Intent.
+	IntentProtectionFinalizer = "dela.phillebaba.io/intent-protection"
+	// ForceDeleteAnnotation can be set on a protected Intent that is being deleted to delete it even though Requests reference it.
+	ForceDeleteAnnotation = "dela.phillebaba.io/force-delete"
+)
+
+// FileSource reads shared data from a file or directory in a volume mounted in the controller.
+type FileSource struct {
+	// Path relative to the file source root of the controller.
+	// A file is shared as a single key named after the file, while each file in a directory is shared as a key.
+	Path string `json:"path"`
+}
+
+// HTTPSource reads shared data from an HTTP endpoint.
+type HTTPSource struct {
+	// URL of an endpoint responding with a JSON object of string values.
+	// Has to match one of the HTTP source URL prefixes allowed by the controller.
+	URL string `json:"url"`
+}
+
+// IntentSource describes data from outside of the cluster that is shared by an Intent.
+// Exactly one of the sources has to be set.
+type IntentSource struct {
+	// Reads data from a file or directory.
+	// +optional
+	File *FileSource `json:"file,omitempty"`
+	// Reads data from an HTTP endpoint.
+	// +optional
+	HTTP *HTTPSource `json:"http,omitempty"`
+	// How often the data is read again.
+	// Defaults to 5m.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// KeyFormat describes the expected format of the value of a key.
+// +kubebuilder:validation:Enum=NonEmpty;PEMCertificate;PEMPrivateKey;JSON;URL
+type KeyFormat string
+
+const (
+	// Value is not empty.
+	KeyFormatNonEmpty KeyFormat = "NonEmpty"
+	// Value contains one or more PEM encoded certificates.
+	KeyFormatPEMCertificate KeyFormat = "PEMCertificate"
+	// Value contains a PEM encoded private key.
+	KeyFormatPEMPrivateKey KeyFormat = "PEMPrivateKey"
+	// Value is valid JSON.
+	KeyFormatJSON KeyFormat = "JSON"
+	// Value is an absolute URL.
+	KeyFormatURL KeyFormat = "URL"
+)
+
+// KeyContract describes a key of the shared Secret.
+type KeyContract struct {
+	// Name of the key.
+	Name string `json:"name"`
+	// Expected format of the value, any value is accepted if unset.
+	// +optional
+	Format KeyFormat `json:"format,omitempty"`
+}
+
+// ContentContract describes the keys that consumers expect in the shared Secret.
+type ContentContract struct {
+	// Keys that have to be present.
+	// +optional
+	Required []KeyContract `json:"required,omitempty"`
+	// Keys that are checked only if present.
+	// +optional
+	Optional []KeyContract `json:"optional,omitempty"`
+}
+
+// RolloutStrategy describes how changes to the shared Secret are rolled out to Requests in waves.
+// Waves are defined either by percentages of the Requests or by a namespace label.
+type RolloutStrategy struct {
+	// Cumulative percentages of Requests that are updated in each wave, such as 10, 50.
+	// Requests that are not part of any wave are updated in a final wave.
+	// +optional
+	Percentages []int32 `json:"percentages,omitempty"`
+	// Namespace label that orders Requests into waves by the value of the label in their namespace.
+	// +optional
+	NamespaceLabel string `json:"namespaceLabel,omitempty"`
+	// Values of the namespace label in wave order, such as canary, staging, prod.
+	// Requests in namespaces with other values are updated in a final wave.
+	// +optional
+	Stages []string `json:"stages,omitempty"`
+	// Time to wait after a wave starts before the next wave can start.
+	// Defaults to 5m.
+	// +optional
+	Pause *metav1.Duration `json:"pause,omitempty"`
+	// Wait for Pods consuming the Secret copies of a wave to be ready before starting the next wave.
+	// +optional
+	WaitForWorkloads bool `json:"waitForWorkloads,omitempty"`
+	// Halt the rollout if a Request in a wave fails, or if consuming Pods are not ready when the pause is over.
+	// +optional
+	HaltOnFailure bool `json:"haltOnFailure,omitempty"`
+}
+
 // IntentSpec defines the desired state of Intent
 type IntentSpec struct {
 	// Reference to Secret that is shared by Intent.
-	SecretName string `json:"secretName"`
+	// Either secretName or source has to be set.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// Source of data from outside the cluster that is shared by Intent.
+	// +optional
+	Source *IntentSource `json:"source,omitempty"`
 	// Namespaces that are whitelisted to access the Intent.
 	// Supports either plain text or regex.
-	// Empty list means allowing all namespaces.
+	// Empty list means allowing all namespaces, unless a namespace selector is set.
 	NamespaceWhitelist []string `json:"namespaceWhitelist,omitempty"`
+	// Label selector of namespaces that are allowed to access the Intent, in addition to the namespace whitelist.
+	// All namespaces are allowed if neither is set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Maximum number of Requests that can be fulfilled by the Intent.
+	// Only granted Requests count, and the Requests created last lose access when the limit is exceeded.
+	// Unset means no limit.
+	// +kubebuilder:validation:Minimum=0
+	MaxRequests *int32 `json:"maxRequests,omitempty"`
+	// Human readable description of the shared Secret.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Contact information for the owner of the shared Secret.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// If the Intent is listed in the IntentCatalog.
+	// Defaults to Public.
+	// +optional
+	Visibility Visibility `json:"visibility,omitempty"`
+	// Stops changes to the shared Secret from being copied to Requests.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// Rolls out changes to the shared Secret in waves, all Requests are updated at once if unset.
+	// +optional
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+	// Keys that the shared Secret has to contain, data that does not fulfill the contract is not shared.
+	// +optional
+	Contract *ContentContract `json:"contract,omitempty"`
+	// Warns about shared certificates that are about to expire.
+	// +optional
+	CertificateExpiry *CertificateExpiryPolicy `json:"certificateExpiry,omitempty"`
+	// Blocks deletion of the Intent while Ready Requests reference it.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+}
+
+// CertificateExpiryPolicy describes how expiring certificates in the shared Secret are handled.
+type CertificateExpiryPolicy struct {
+	// Time before a certificate expires when warnings start. Defaults to 720h.
+	// +optional
+	WarningWindow *metav1.Duration `json:"warningWindow,omitempty"`
+	// Marks the Intent as not ready once a certificate has expired, so that no more copies are made.
+	// +optional
+	NotReadyWhenExpired bool `json:"notReadyWhenExpired,omitempty"`
 }
 
 // IntentState represents the current state of a Intent.
@@ -20,15 +179,97 @@ type IntentState string
 const (
 	// Error when locating referenced Secert.
 	IntentStateError IntentState = "Error"
+	// Waiting for the referenced Secret to be created.
+	IntentStatePending IntentState = "Pending"
 	// Secret has been located.
 	IntentStateReady IntentState = "Ready"
+	// Intent is not allowed by a SharePolicy.
+	IntentStatePolicyViolation IntentState = "PolicyViolation"
+	// Shared data does not fulfill the content contract.
+	IntentStateContractViolation IntentState = "ContractViolation"
+	// Shared certificate has expired.
+	IntentStateCertificateExpired IntentState = "CertificateExpired"
 )
 
+// RolloutPhase represents the current phase of a rollout.
+type RolloutPhase string
+
+const (
+	// Rollout is updating Requests wave by wave.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// All Requests have been updated.
+	RolloutPhaseComplete RolloutPhase = "Complete"
+	// Rollout was halted because a wave failed.
+	RolloutPhaseHalted RolloutPhase = "Halted"
+)
+
+// RolloutStatus describes the progress of rolling out a version of the shared Secret.
+type RolloutStatus struct {
+	// Version of the shared data that is rolled out.
+	Version string `json:"version"`
+	// Current phase of the rollout.
+	Phase RolloutPhase `json:"phase"`
+	// Index of the current wave, starting at zero.
+	Wave int32 `json:"wave"`
+	// Total number of waves.
+	Waves int32 `json:"waves"`
+	// Number of Requests that have been updated to the version.
+	UpdatedRequests int32 `json:"updatedRequests"`
+	// Total number of Requests.
+	TotalRequests int32 `json:"totalRequests"`
+	// Time when the current wave started.
+	// +optional
+	WaveStartTime *metav1.Time `json:"waveStartTime,omitempty"`
+	// Human readable message describing the progress.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CertificateInfo describes a PEM encoded certificate in the shared Secret.
+// Only the first certificate of each key is described, which is the leaf of a certificate chain.
+type CertificateInfo struct {
+	// Key containing the certificate.
+	Key string `json:"key"`
+	// Subject of the certificate.
+	Subject string `json:"subject"`
+	// Issuer of the certificate.
+	Issuer string `json:"issuer"`
+	// DNS names, IP addresses, email addresses and URIs of the certificate.
+	// +optional
+	SubjectAltNames []string `json:"subjectAltNames,omitempty"`
+	// Time when the certificate becomes valid.
+	NotBefore metav1.Time `json:"notBefore"`
+	// Time when the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
 // IntentStatus defines the observed state of Intent
 type IntentStatus struct {
 	State IntentState `json:"state"`
+	// Generation of the Intent that the status was last computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Type of the shared Secret.
+	// +optional
+	SecretType corev1.SecretType `json:"secretType,omitempty"`
+	// Names of the keys in the shared Secret.
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+	// Version of the shared data, changes every time the data changes.
+	// +optional
+	SourceVersion string `json:"sourceVersion,omitempty"`
+	// Progress of rolling out the shared Secret, when a rollout strategy is set.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// Certificates found in the shared Secret.
+	// +optional
+	Certificates []CertificateInfo `json:"certificates,omitempty"`
+	// Conditions describing the current state of the Intent.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state"
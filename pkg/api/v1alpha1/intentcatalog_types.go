@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IntentCatalogEntry describes an Intent that can be requested.
+type IntentCatalogEntry struct {
+	// Identifier of the Intent.
+	IntentRef IntentReference `json:"intentRef"`
+	// Human readable description of the shared Secret.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Contact information for the owner of the shared Secret.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// Type of the shared Secret.
+	// +optional
+	SecretType corev1.SecretType `json:"secretType,omitempty"`
+	// Names of the keys in the shared Secret.
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// IntentCatalog lists the Intents that Requests in the Namespace with the same name are allowed to request.
+// It is maintained by the controller and read only for users.
+type IntentCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Intents that can be requested.
+	// +optional
+	Entries []IntentCatalogEntry `json:"entries,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IntentCatalogList contains a list of IntentCatalog
+type IntentCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IntentCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IntentCatalog{}, &IntentCatalogList{})
+}
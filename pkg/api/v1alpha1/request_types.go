@@ -12,12 +12,123 @@ type IntentReference struct {
 	Namespace string `json:"namespace"`
 }
 
+// DriftPolicy describes how changes made directly to a Secret copy are handled.
+// +kubebuilder:validation:Enum=Repair;Report;Ignore
+type DriftPolicy string
+
+const (
+	// Drift is reported and the Secret copy is restored.
+	DriftPolicyRepair DriftPolicy = "Repair"
+	// Drift is reported but the Secret copy is left as is.
+	DriftPolicyReport DriftPolicy = "Report"
+	// Drift is neither reported nor repaired.
+	DriftPolicyIgnore DriftPolicy = "Ignore"
+)
+
+// AdoptionPolicy describes if an existing Secret that is not managed by dela can be taken over as the Secret copy.
+// +kubebuilder:validation:Enum=Never;IfIdentical;Always
+type AdoptionPolicy string
+
+const (
+	// Existing Secrets are never adopted.
+	AdoptionPolicyNever AdoptionPolicy = "Never"
+	// Existing Secrets are adopted only if their data is identical to the source.
+	AdoptionPolicyIfIdentical AdoptionPolicy = "IfIdentical"
+	// Existing Secrets are always adopted and overwritten with the source data.
+	AdoptionPolicyAlways AdoptionPolicy = "Always"
+)
+
+const (
+	// SourceResourceVersionAnnotation is set on a Secret copy to the resource version of the source Secret it was copied from.
+	SourceResourceVersionAnnotation = "dela.phillebaba.io/source-resource-version"
+	// DataVersionAnnotation is set on a Secret copy to the version of the data it was written with, so that changes made directly to the copy are detected.
+	DataVersionAnnotation = "dela.phillebaba.io/data-version"
+	// DivergeUntilAnnotation can be set on a Secret copy to an RFC3339 timestamp to allow it to diverge from the source until then.
+	DivergeUntilAnnotation = "dela.phillebaba.io/diverge-until"
+	// RollbackAnnotation can be set on a versioned Request to pin it to the version before the current one.
+	RollbackAnnotation = "dela.phillebaba.io/rollback"
+	// RequestLabel is set on versioned Secret copies to the name of the Request they belong to.
+	RequestLabel = "dela.phillebaba.io/request"
+	// VersionLabel is set on versioned Secret copies to the version of the data they contain.
+	VersionLabel = "dela.phillebaba.io/version"
+	// SyncTokenAnnotation is changed on a Request with the Manual sync policy to update the Secret copy.
+	SyncTokenAnnotation = "dela.phillebaba.io/sync-token"
+	// ImmutableAnnotation is set on Secret copies that are created immutable.
+	ImmutableAnnotation = "dela.phillebaba.io/immutable"
+	// ManagedLabel is set to true on shared Secrets and Secret copies, so that only Secrets managed by dela have to be cached.
+	ManagedLabel = "dela.phillebaba.io/managed"
+	// RequestOrphanFinalizer is set on Requests with the Orphan deletion policy, and orphans the Secret copies before the Request is deleted.
+	RequestOrphanFinalizer = "dela.phillebaba.io/orphan"
+)
+
+// DeletionPolicy describes what happens to the Secret copies when the Request is deleted.
+// +kubebuilder:validation:Enum=Delete;Orphan
+type DeletionPolicy string
+
+const (
+	// Secret copies are garbage collected together with the Request.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// Secret copies are kept as unmanaged Secrets.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// SyncPolicy describes when the Secret copy is updated with changes to the source.
+// +kubebuilder:validation:Enum=Automatic;Manual
+type SyncPolicy string
+
+const (
+	// Secret copy is updated as soon as the source changes.
+	SyncPolicyAutomatic SyncPolicy = "Automatic"
+	// Secret copy is only updated when the sync token annotation of the Request changes.
+	SyncPolicyManual SyncPolicy = "Manual"
+)
+
+// Versioning configures immutable versioned copies of the shared Secret.
+// Each version of the data is copied to a Secret named after the Secret copy and the version,
+// while the Secret copy contains the data of the current version.
+type Versioning struct {
+	// Number of versions to keep, including the current version.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Keep *int32 `json:"keep,omitempty"`
+	// Version to pin the Secret copy to instead of the latest version.
+	// +optional
+	Pin string `json:"pin,omitempty"`
+}
+
 // RequestSpec defines the desired state of Request
 type RequestSpec struct {
 	// Identifier of Intent to make Request for.
 	IntentRef IntentReference `json:"intentRef"`
 	// Overrides ObjectMeta of the Secret copy.
 	SecretObjectMeta metav1.ObjectMeta `json:"secretMetadata"`
+	// How changes made directly to the Secret copy are handled.
+	// Defaults to Repair.
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+	// If an existing Secret that is not managed by dela can be adopted as the Secret copy.
+	// Defaults to Never.
+	// +optional
+	AdoptionPolicy AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+	// Keeps immutable versioned copies of the Secret, disabled if unset.
+	// +optional
+	Versioning *Versioning `json:"versioning,omitempty"`
+	// Stops all changes to the Secret copy while the status is still reported.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// When the Secret copy is updated with changes to the source.
+	// Defaults to Automatic.
+	// +optional
+	SyncPolicy SyncPolicy `json:"syncPolicy,omitempty"`
+	// Creates the Secret copy as immutable, which is replaced instead of updated when the source changes.
+	// Pods have to be restarted to read the replaced Secret copy.
+	// +optional
+	Immutable bool `json:"immutable,omitempty"`
+	// What happens to the Secret copies when the Request is deleted.
+	// Defaults to Delete.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // RequestState represents the current state of a Request.
@@ -26,15 +137,67 @@ type RequestState string
 const (
 	// Error has occured when copying the Secret.
 	RequestStateError RequestState = "Error"
+	// Waiting for the Intent or its Secret to become available.
+	RequestStatePending RequestState = "Pending"
 	// Request fulfilled and the Secret has been copied.
 	RequestStateReady RequestState = "Ready"
+	// Request can not be fulfilled as it would exceed a quota.
+	RequestStateQuotaExceeded RequestState = "QuotaExceeded"
+	// Request is not allowed by a SharePolicy.
+	RequestStatePolicyViolation RequestState = "PolicyViolation"
+	// Destination Secret is claimed by another Request.
+	RequestStateConflict RequestState = "Conflict"
+	// Request or its Intent is suspended and the Secret copy is not changed.
+	RequestStateSuspended RequestState = "Suspended"
+	// Shared data does not fulfill the content contract of the Intent and is not copied.
+	RequestStateContractViolation RequestState = "ContractViolation"
+)
+
+const (
+	// Secret copy differs from the source Secret.
+	RequestConditionDrifted ConditionType = "Drifted"
+	// Secret copy has not been updated with the latest version of the source.
+	RequestConditionOutOfSync ConditionType = "OutOfSync"
+	// Request was denied access to the Intent, the reason is the reason of the last denial.
+	RequestConditionDenied ConditionType = "Denied"
 )
 
 // RequestStatus defines the observed state of Request
 type RequestStatus struct {
 	State RequestState `json:"state"`
+	// Generation of the Request that the status was last computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Version of the data in the Secret copy, when versioning is enabled.
+	// +optional
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	// Versions that are kept, newest first.
+	// +optional
+	Versions []string `json:"versions,omitempty"`
+	// Version that the Secret copy was rolled back to, until the spec of the Request changes.
+	// +optional
+	RolledBackTo string `json:"rolledBackTo,omitempty"`
+	// Version of the source data.
+	// +optional
+	SourceVersion string `json:"sourceVersion,omitempty"`
+	// Version of the data in the Secret copy.
+	// +optional
+	SyncedVersion string `json:"syncedVersion,omitempty"`
+	// Last time the Secret copy was changed.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Time since the Secret copy has been behind the source, unset when in sync.
+	// +optional
+	OutOfSyncSince *metav1.Time `json:"outOfSyncSince,omitempty"`
+	// Sync token of the last manual sync.
+	// +optional
+	LastSyncToken string `json:"lastSyncToken,omitempty"`
+	// Conditions describing the current state of the Request.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state"
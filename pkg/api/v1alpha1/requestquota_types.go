@@ -0,0 +1,45 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RequestQuotaSpec defines the desired state of RequestQuota
+type RequestQuotaSpec struct {
+	// Namespaces that the quota applies to.
+	// Supports either plain text or regex.
+	// Empty list means applying to all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Maximum number of Requests that can be fulfilled in each Namespace.
+	// +kubebuilder:validation:Minimum=0
+	MaxRequests int32 `json:"maxRequests"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Max Requests",type="integer",JSONPath=".spec.maxRequests"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// RequestQuota is the Schema for the RequestQuotas API
+type RequestQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RequestQuotaSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RequestQuotaList contains a list of RequestQuota
+type RequestQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RequestQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RequestQuota{}, &RequestQuotaList{})
+}
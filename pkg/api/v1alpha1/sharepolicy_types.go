@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SharePolicyRule matches sharing of Secrets from source Namespaces to destination Namespaces.
+type SharePolicyRule struct {
+	// Namespaces of Intents that the rule matches.
+	// Supports either plain text or regex.
+	// Empty list means matching all namespaces.
+	SourceNamespaces []string `json:"sourceNamespaces,omitempty"`
+	// Namespaces of Requests that the rule matches.
+	// Supports either plain text or regex.
+	// Empty list means matching all namespaces.
+	DestinationNamespaces []string `json:"destinationNamespaces,omitempty"`
+}
+
+// SharePolicySpec defines the desired state of SharePolicy
+type SharePolicySpec struct {
+	// Label selector that Namespaces have to match to contain Intents.
+	// Unset means allowing Intents in all namespaces.
+	// +optional
+	IntentNamespaceSelector *metav1.LabelSelector `json:"intentNamespaceSelector,omitempty"`
+	// Rules denying Secrets to be shared.
+	// A rule without destination namespaces denies Intents in the source namespaces.
+	// +optional
+	Deny []SharePolicyRule `json:"deny,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SharePolicy is the Schema for the SharePolicies API
+type SharePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SharePolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharePolicyList contains a list of SharePolicy
+type SharePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SharePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SharePolicy{}, &SharePolicyList{})
+}
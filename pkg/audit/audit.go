@@ -0,0 +1,212 @@
+// Package audit writes structured records of the decisions made by the controllers.
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Action is the decision that a Record describes.
+type Action string
+
+const (
+	// Access to an Intent was granted.
+	ActionGranted Action = "Granted"
+	// Access to an Intent was denied.
+	ActionDenied Action = "Denied"
+	// Previously granted access to an Intent was withdrawn.
+	ActionRevoked Action = "Revoked"
+	// Secret copy was created.
+	ActionCreated Action = "Created"
+	// Secret copy was updated.
+	ActionUpdated Action = "Updated"
+	// Secret copy was deleted.
+	ActionDeleted Action = "Deleted"
+	// Existing Secret was adopted as the Secret copy.
+	ActionAdopted Action = "Adopted"
+	// Secret copy was released from the Request and kept as an unmanaged Secret.
+	ActionOrphaned Action = "Orphaned"
+)
+
+// Record is a structured audit record of a single decision.
+type Record struct {
+	Time                  time.Time `json:"time"`
+	Action                Action    `json:"action"`
+	Reason                string    `json:"reason,omitempty"`
+	Intent                string    `json:"intent,omitempty"`
+	Request               string    `json:"request,omitempty"`
+	SourceNamespace       string    `json:"sourceNamespace,omitempty"`
+	DestinationNamespace  string    `json:"destinationNamespace,omitempty"`
+	Secret                string    `json:"secret,omitempty"`
+	SourceResourceVersion string    `json:"sourceResourceVersion,omitempty"`
+	ContentHash           string    `json:"contentHash,omitempty"`
+	DryRun                bool      `json:"dryRun,omitempty"`
+}
+
+// Sink receives audit records.
+type Sink interface {
+	Write(record Record) error
+}
+
+// NewSink creates a Sink from a target.
+// The target is either "stdout", an http(s) URL, or a file path optionally prefixed with "file://".
+func NewSink(target string) (Sink, error) {
+	switch {
+	case target == "stdout":
+		return NewWriterSink(os.Stdout), nil
+	case strings.HasPrefix(target, "http://"), strings.HasPrefix(target, "https://"):
+		return NewHTTPSink(target), nil
+	default:
+		file, err := os.OpenFile(strings.TrimPrefix(target, "file://"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return NewWriterSink(file), nil
+	}
+}
+
+// WriterSink writes records as JSON lines to a writer.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink creates a WriterSink that writes to w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Write(record Record) error {
+	b, err := marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
+
+// httpBufferSize is the number of records buffered by a HTTPSink before records are dropped.
+const httpBufferSize = 1000
+
+// HTTPSink posts each record as JSON to an HTTP endpoint.
+// Records are buffered and posted in the background, so that a slow endpoint does not hold up the controllers.
+type HTTPSink struct {
+	URL    string
+	Client *http.Client
+	// OnError is called when a record could not be posted, defaults to logging the error.
+	OnError func(record Record, err error)
+
+	start   sync.Once
+	records chan Record
+	done    chan struct{}
+}
+
+// NewHTTPSink creates a HTTPSink that posts to url.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+		OnError: func(record Record, err error) {
+			log.Log.WithName("audit").Error(err, "Could not post audit record", "action", record.Action, "intent", record.Intent, "request", record.Request)
+		},
+	}
+}
+
+// Write queues the record to be posted, and returns an error if the buffer is full and the record is dropped.
+func (s *HTTPSink) Write(record Record) error {
+	s.start.Do(func() {
+		s.records = make(chan Record, httpBufferSize)
+		s.done = make(chan struct{})
+		go s.run()
+	})
+
+	// The time of the decision is recorded, not the time it is posted
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	select {
+	case s.records <- record:
+		return nil
+	default:
+		return fmt.Errorf("audit buffer is full, dropping record")
+	}
+}
+
+// Close stops accepting records, and waits for the buffered records to be posted.
+func (s *HTTPSink) Close() error {
+	s.start.Do(func() {})
+	if s.records == nil {
+		return nil
+	}
+	close(s.records)
+	<-s.done
+	return nil
+}
+
+// run posts buffered records until the sink is closed.
+func (s *HTTPSink) run() {
+	defer close(s.done)
+	for record := range s.records {
+		if err := s.post(record); err != nil && s.OnError != nil {
+			s.OnError(record, err)
+		}
+	}
+}
+
+// post posts the record to the endpoint.
+func (s *HTTPSink) post(record Record) error {
+	b, err := marshal(record)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.Client.Post(s.URL, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ContentHash returns a SHA-256 hash of the Secret data, independent of key order.
+func ContentHash(data map[string][]byte) string {
+	keys := []string{}
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%d:%s%d:", len(k), k, len(data[k]))
+		h.Write(data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// marshal encodes the record, setting the time if it is not already set.
+func marshal(record Record) ([]byte, error) {
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	return json.Marshal(record)
+}
@@ -0,0 +1,122 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit", func() {
+	record := Record{
+		Action:               ActionGranted,
+		Intent:               "source/intent",
+		Request:              "destination/request",
+		SourceNamespace:      "source",
+		DestinationNamespace: "destination",
+		Secret:               "secret",
+	}
+
+	Context("WriterSink", func() {
+		It("Should write records as JSON lines", func() {
+			buf := &bytes.Buffer{}
+			sink := NewWriterSink(buf)
+			Expect(sink.Write(record)).Should(Succeed())
+			Expect(sink.Write(record)).Should(Succeed())
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			Expect(lines).To(HaveLen(2))
+			written := Record{}
+			Expect(json.Unmarshal([]byte(lines[0]), &written)).Should(Succeed())
+			Expect(written.Time.IsZero()).To(BeFalse())
+			written.Time = record.Time
+			Expect(written).To(Equal(record))
+		})
+	})
+
+	Context("HTTPSink", func() {
+		It("Should post records to the endpoint", func() {
+			received := make(chan Record, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				written := Record{}
+				Expect(json.NewDecoder(req.Body).Decode(&written)).Should(Succeed())
+				received <- written
+			}))
+			defer server.Close()
+
+			sink, err := NewSink(server.URL)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sink.Write(record)).Should(Succeed())
+			Expect((<-received).Action).To(Equal(ActionGranted))
+		})
+
+		It("Should fail on non success status", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer server.Close()
+
+			failed := make(chan error, 1)
+			sink := NewHTTPSink(server.URL)
+			sink.OnError = func(_ Record, err error) {
+				failed <- err
+			}
+			Expect(sink.Write(record)).Should(Succeed())
+			Expect(<-failed).Should(HaveOccurred())
+		})
+
+		It("Should not block on a slow endpoint", func() {
+			release := make(chan struct{})
+			received := make(chan Record, 2)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				<-release
+				written := Record{}
+				Expect(json.NewDecoder(req.Body).Decode(&written)).Should(Succeed())
+				received <- written
+			}))
+			defer server.Close()
+
+			sink := NewHTTPSink(server.URL)
+			Expect(sink.Write(record)).Should(Succeed())
+			Expect(sink.Write(record)).Should(Succeed())
+			close(release)
+			Expect(sink.Close()).Should(Succeed())
+			Expect(received).To(HaveLen(2))
+		})
+	})
+
+	Context("File sink", func() {
+		It("Should append records to the file", func() {
+			dir, err := ioutil.TempDir("", "audit")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "audit.log")
+			for i := 0; i < 2; i++ {
+				sink, err := NewSink("file://" + path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sink.Write(record)).Should(Succeed())
+			}
+
+			b, err := ioutil.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.Count(string(b), "\n")).To(Equal(2))
+		})
+	})
+
+	Context("ContentHash", func() {
+		It("Should only depend on content", func() {
+			a := ContentHash(map[string][]byte{"foo": []byte("bar"), "baz": []byte("qux")})
+			b := ContentHash(map[string][]byte{"baz": []byte("qux"), "foo": []byte("bar")})
+			Expect(a).To(Equal(b))
+			Expect(ContentHash(map[string][]byte{"foob": []byte("ar"), "baz": []byte("qux")})).ToNot(Equal(a))
+		})
+	})
+})
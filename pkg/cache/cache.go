@@ -0,0 +1,319 @@
+// Package cache provides a controller-runtime cache that only caches the Secrets selected by a label selector.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultResync is the resync period used when the cache options do not set one, the same as controller-runtime.
+const defaultResync = 10 * time.Hour
+
+// allNamespaces is the namespace key used by field indexes to list objects regardless of their namespace.
+const allNamespaces = "__all_namespaces"
+
+var secretGVK = corev1.SchemeGroupVersion.WithKind("Secret")
+
+// SelectSecrets returns a NewCacheFunc that only caches Secrets matching the selector in the given namespaces,
+// or in all namespaces if none are given. All other objects are cached by the cache created by newCache.
+// Secrets that do not match the selector are not found in the cache and have to be read from the API server.
+func SelectSecrets(selector labels.Selector, namespaces []string, newCache crcache.NewCacheFunc) crcache.NewCacheFunc {
+	return func(config *rest.Config, opts crcache.Options) (crcache.Cache, error) {
+		base, err := newCache(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		clientset, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		resync := defaultResync
+		if opts.Resync != nil {
+			resync = *opts.Resync
+		}
+
+		return newSecretCache(base, clientset, selector, namespaces, resync), nil
+	}
+}
+
+// secretCache caches Secrets with label selected informers, and delegates all other objects to the base cache.
+type secretCache struct {
+	crcache.Cache
+	// Secret informers by namespace, an empty namespace covers all namespaces.
+	informers map[string]toolscache.SharedIndexInformer
+}
+
+var _ crcache.Cache = &secretCache{}
+
+func newSecretCache(base crcache.Cache, clientset kubernetes.Interface, selector labels.Selector, namespaces []string, resync time.Duration) *secretCache {
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	tweak := func(opts *metav1.ListOptions) {
+		opts.LabelSelector = selector.String()
+	}
+	informers := map[string]toolscache.SharedIndexInformer{}
+	for _, ns := range namespaces {
+		indexers := toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc}
+		informers[ns] = coreinformers.NewFilteredSecretInformer(clientset, ns, resync, indexers, tweak)
+	}
+
+	return &secretCache{Cache: base, informers: informers}
+}
+
+// isSecret checks if the object is a Secret or a list of Secrets.
+func isSecret(obj runtime.Object) bool {
+	switch obj.(type) {
+	case *corev1.Secret, *corev1.SecretList:
+		return true
+	}
+
+	return false
+}
+
+// informerFor returns the informer that caches Secrets in the namespace.
+func (c *secretCache) informerFor(namespace string) (toolscache.SharedIndexInformer, error) {
+	if informer, ok := c.informers[metav1.NamespaceAll]; ok {
+		return informer, nil
+	}
+	if informer, ok := c.informers[namespace]; ok {
+		return informer, nil
+	}
+
+	return nil, fmt.Errorf("Secrets in namespace %q are not cached", namespace)
+}
+
+func (c *secretCache) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return c.Cache.Get(ctx, key, obj)
+	}
+
+	informer, err := c.informerFor(key.Namespace)
+	if err != nil {
+		return err
+	}
+	item, exists, err := informer.GetIndexer().GetByKey(key.String())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+	}
+	item.(*corev1.Secret).DeepCopyInto(secret)
+	secret.SetGroupVersionKind(secretGVK)
+
+	return nil
+}
+
+func (c *secretCache) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	secretList, ok := list.(*corev1.SecretList)
+	if !ok {
+		return c.Cache.List(ctx, list, opts...)
+	}
+
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	informers := []toolscache.SharedIndexInformer{}
+	if listOpts.Namespace != "" {
+		informer, err := c.informerFor(listOpts.Namespace)
+		if err != nil {
+			return err
+		}
+		informers = append(informers, informer)
+	} else {
+		for _, informer := range c.informers {
+			informers = append(informers, informer)
+		}
+	}
+
+	items := []corev1.Secret{}
+	for _, informer := range informers {
+		objs, err := listIndexer(informer.GetIndexer(), listOpts)
+		if err != nil {
+			return err
+		}
+		for _, obj := range objs {
+			secret := obj.(*corev1.Secret)
+			if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Matches(labels.Set(secret.Labels)) {
+				continue
+			}
+			items = append(items, *secret.DeepCopy())
+		}
+	}
+	secretList.Items = items
+	secretList.ResourceVersion = ""
+
+	return nil
+}
+
+// listIndexer lists the objects of the indexer matching the namespace and field selector of the options.
+func listIndexer(indexer toolscache.Indexer, opts client.ListOptions) ([]interface{}, error) {
+	if opts.FieldSelector != nil && !opts.FieldSelector.Empty() {
+		field, value, err := exactFieldMatch(opts.FieldSelector)
+		if err != nil {
+			return nil, err
+		}
+		namespace := opts.Namespace
+		if namespace == "" {
+			namespace = allNamespaces
+		}
+		return indexer.ByIndex(fieldIndexName(field), namespace+"/"+value)
+	}
+	if opts.Namespace != "" {
+		return indexer.ByIndex(toolscache.NamespaceIndex, opts.Namespace)
+	}
+
+	return indexer.List(), nil
+}
+
+// exactFieldMatch returns the field and value of a field selector requiring a single exact match,
+// which are the only field selectors supported by field indexes.
+func exactFieldMatch(selector fields.Selector) (string, string, error) {
+	requirements := selector.Requirements()
+	if len(requirements) != 1 {
+		return "", "", fmt.Errorf("field selector %q has to require exactly one field", selector)
+	}
+	requirement := requirements[0]
+	if requirement.Operator != selection.Equals && requirement.Operator != selection.DoubleEquals {
+		return "", "", fmt.Errorf("field selector %q has to require an exact match", selector)
+	}
+
+	return requirement.Field, requirement.Value, nil
+}
+
+// fieldIndexName returns the name of the index for the field.
+func fieldIndexName(field string) string {
+	return "field:" + field
+}
+
+func (c *secretCache) IndexField(obj runtime.Object, field string, extractValue client.IndexerFunc) error {
+	if !isSecret(obj) {
+		return c.Cache.IndexField(obj, field, extractValue)
+	}
+
+	// Index each value both in the namespace of the object and across all namespaces
+	indexFunc := func(raw interface{}) ([]string, error) {
+		obj, ok := raw.(runtime.Object)
+		if !ok {
+			return nil, fmt.Errorf("object of type %T is not an Object", raw)
+		}
+		accessor, err := apimeta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		values := []string{}
+		for _, value := range extractValue(obj) {
+			values = append(values, accessor.GetNamespace()+"/"+value, allNamespaces+"/"+value)
+		}
+		return values, nil
+	}
+	for _, informer := range c.informers {
+		if err := informer.AddIndexers(toolscache.Indexers{fieldIndexName(field): indexFunc}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *secretCache) GetInformer(obj runtime.Object) (crcache.Informer, error) {
+	if !isSecret(obj) {
+		return c.Cache.GetInformer(obj)
+	}
+
+	return c.secretInformer(), nil
+}
+
+func (c *secretCache) GetInformerForKind(gvk schema.GroupVersionKind) (crcache.Informer, error) {
+	if gvk != secretGVK && gvk != corev1.SchemeGroupVersion.WithKind("SecretList") {
+		return c.Cache.GetInformerForKind(gvk)
+	}
+
+	return c.secretInformer(), nil
+}
+
+// secretInformer returns a single informer for Secrets across all cached namespaces.
+func (c *secretCache) secretInformer() crcache.Informer {
+	informers := multiInformer{}
+	for _, informer := range c.informers {
+		informers = append(informers, informer)
+	}
+	if len(informers) == 1 {
+		return informers[0]
+	}
+
+	return informers
+}
+
+func (c *secretCache) Start(stopCh <-chan struct{}) error {
+	for _, informer := range c.informers {
+		go informer.Run(stopCh)
+	}
+
+	return c.Cache.Start(stopCh)
+}
+
+func (c *secretCache) WaitForCacheSync(stop <-chan struct{}) bool {
+	synced := []toolscache.InformerSynced{}
+	for _, informer := range c.informers {
+		synced = append(synced, informer.HasSynced)
+	}
+	if !toolscache.WaitForCacheSync(stop, synced...) {
+		return false
+	}
+
+	return c.Cache.WaitForCacheSync(stop)
+}
+
+// multiInformer combines the informers of several namespaces into a single informer.
+type multiInformer []toolscache.SharedIndexInformer
+
+func (m multiInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	for _, informer := range m {
+		informer.AddEventHandler(handler)
+	}
+}
+
+func (m multiInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	for _, informer := range m {
+		informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	}
+}
+
+func (m multiInformer) AddIndexers(indexers toolscache.Indexers) error {
+	for _, informer := range m {
+		if err := informer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m multiInformer) HasSynced() bool {
+	for _, informer := range m {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+
+	return true
+}
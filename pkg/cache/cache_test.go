@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var managedSelector = labels.SelectorFromSet(labels.Set{"managed": "true"})
+
+func testSecret(namespace, name string, managed bool, size int) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string][]byte{"value": make([]byte, size)},
+	}
+	if managed {
+		secret.Labels = map[string]string{"managed": "true"}
+	}
+
+	return secret
+}
+
+var _ = Describe("Secret Cache", func() {
+	ctx := context.TODO()
+	var stop chan struct{}
+	var c *secretCache
+
+	BeforeEach(func() {
+		stop = make(chan struct{})
+		clientset := fake.NewSimpleClientset(
+			testSecret("a", "managed", true, 1),
+			testSecret("a", "unmanaged", false, 1),
+			testSecret("b", "managed", true, 1),
+		)
+		c = newSecretCache(&informertest.FakeInformers{}, clientset, managedSelector, nil, time.Hour)
+		Expect(c.IndexField(&corev1.Secret{}, "name", func(obj k8sruntime.Object) []string {
+			return []string{obj.(*corev1.Secret).Name}
+		})).Should(Succeed())
+		go func() {
+			_ = c.Start(stop)
+		}()
+		Expect(c.WaitForCacheSync(stop)).To(BeTrue())
+	})
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("Only gets selected Secrets", func() {
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "a", Name: "managed"}, secret)).Should(Succeed())
+		Expect(secret.Labels).To(HaveKeyWithValue("managed", "true"))
+
+		err := c.Get(ctx, types.NamespacedName{Namespace: "a", Name: "unmanaged"}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Lists selected Secrets by namespace and field index", func() {
+		secrets := &corev1.SecretList{}
+		Expect(c.List(ctx, secrets)).Should(Succeed())
+		Expect(secrets.Items).To(HaveLen(2))
+
+		Expect(c.List(ctx, secrets, client.InNamespace("a"))).Should(Succeed())
+		Expect(secrets.Items).To(HaveLen(1))
+
+		Expect(c.List(ctx, secrets, client.MatchingFields{"name": "managed"})).Should(Succeed())
+		Expect(secrets.Items).To(HaveLen(2))
+
+		Expect(c.List(ctx, secrets, client.InNamespace("b"), client.MatchingFields{"name": "managed"})).Should(Succeed())
+		Expect(secrets.Items).To(HaveLen(1))
+		Expect(secrets.Items[0].Namespace).To(Equal("b"))
+	})
+
+	It("Caches each listed namespace", func() {
+		clientset := fake.NewSimpleClientset(testSecret("a", "managed", true, 1), testSecret("c", "managed", true, 1))
+		nsCache := newSecretCache(&informertest.FakeInformers{}, clientset, managedSelector, []string{"a", "b"}, time.Hour)
+		go func() {
+			_ = nsCache.Start(stop)
+		}()
+		Expect(nsCache.WaitForCacheSync(stop)).To(BeTrue())
+
+		secrets := &corev1.SecretList{}
+		Expect(nsCache.List(ctx, secrets)).Should(Succeed())
+		Expect(secrets.Items).To(HaveLen(1))
+		Expect(nsCache.Get(ctx, types.NamespacedName{Namespace: "c", Name: "managed"}, &corev1.Secret{})).ShouldNot(Succeed())
+	})
+})
+
+// BenchmarkSecretCache reports the heap used to cache 50k Secrets of 2KiB, of which 1% are managed by dela,
+// when caching all Secrets compared to only the managed Secrets.
+func BenchmarkSecretCache(b *testing.B) {
+	const total = 50000
+	const managed = total / 100
+	objs := []k8sruntime.Object{}
+	for i := 0; i < total; i++ {
+		objs = append(objs, testSecret(fmt.Sprintf("ns-%d", i%100), fmt.Sprintf("secret-%d", i), i%(total/managed) == 0, 2048))
+	}
+	clientset := fake.NewSimpleClientset(objs...)
+
+	for _, bm := range []struct {
+		name     string
+		selector labels.Selector
+	}{
+		{name: "All", selector: labels.Everything()},
+		{name: "Managed", selector: managedSelector},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+
+				stop := make(chan struct{})
+				c := newSecretCache(&informertest.FakeInformers{}, clientset, bm.selector, nil, time.Hour)
+				go func() {
+					_ = c.Start(stop)
+				}()
+				c.WaitForCacheSync(stop)
+
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc))/(1<<20), "heap-MiB")
+				b.ReportMetric(float64(len(c.informers[metav1.NamespaceAll].GetStore().ListKeys())), "secrets")
+
+				// Give the informer time to stop so that its memory is not counted by the next iteration
+				b.StopTimer()
+				close(stop)
+				time.Sleep(time.Second)
+				b.StartTimer()
+			}
+		})
+	}
+}
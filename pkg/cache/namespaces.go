@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// MultiNamespaced returns a NewCacheFunc that caches namespaced objects in the given namespaces, and cluster scoped objects
+// in a separate cache across the cluster. The multi namespace cache of controller-runtime can not get cluster scoped objects,
+// and lists them once for each namespace.
+func MultiNamespaced(namespaces []string) crcache.NewCacheFunc {
+	return func(config *rest.Config, opts crcache.Options) (crcache.Cache, error) {
+		if opts.Mapper == nil {
+			return nil, fmt.Errorf("a RESTMapper is required to tell cluster scoped objects apart")
+		}
+		namespaced, err := crcache.MultiNamespacedCacheBuilder(namespaces)(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		opts.Namespace = ""
+		cluster, err := crcache.New(config, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return newClusterScopedCache(namespaced, cluster, opts.Scheme, opts.Mapper), nil
+	}
+}
+
+// clusterScopedCache delegates cluster scoped objects to the cluster cache, and all other objects to the namespaced cache.
+type clusterScopedCache struct {
+	crcache.Cache
+	cluster crcache.Cache
+	scheme  *runtime.Scheme
+	mapper  apimeta.RESTMapper
+}
+
+var _ crcache.Cache = &clusterScopedCache{}
+
+func newClusterScopedCache(namespaced, cluster crcache.Cache, scheme *runtime.Scheme, mapper apimeta.RESTMapper) *clusterScopedCache {
+	return &clusterScopedCache{Cache: namespaced, cluster: cluster, scheme: scheme, mapper: mapper}
+}
+
+// cacheFor returns the cache for the object, which may also be a list.
+func (c *clusterScopedCache) cacheFor(obj runtime.Object) (crcache.Cache, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	if apimeta.IsListType(obj) {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+
+	return c.cacheForKind(gvk)
+}
+
+// cacheForKind returns the cache for the kind.
+func (c *clusterScopedCache) cacheForKind(gvk schema.GroupVersionKind) (crcache.Cache, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == apimeta.RESTScopeNameRoot {
+		return c.cluster, nil
+	}
+
+	return c.Cache, nil
+}
+
+func (c *clusterScopedCache) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	cache, err := c.cacheFor(obj)
+	if err != nil {
+		return err
+	}
+
+	return cache.Get(ctx, key, obj)
+}
+
+func (c *clusterScopedCache) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	cache, err := c.cacheFor(list)
+	if err != nil {
+		return err
+	}
+
+	return cache.List(ctx, list, opts...)
+}
+
+func (c *clusterScopedCache) IndexField(obj runtime.Object, field string, extractValue client.IndexerFunc) error {
+	cache, err := c.cacheFor(obj)
+	if err != nil {
+		return err
+	}
+
+	return cache.IndexField(obj, field, extractValue)
+}
+
+func (c *clusterScopedCache) GetInformer(obj runtime.Object) (crcache.Informer, error) {
+	cache, err := c.cacheFor(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.GetInformer(obj)
+}
+
+func (c *clusterScopedCache) GetInformerForKind(gvk schema.GroupVersionKind) (crcache.Informer, error) {
+	kind := gvk
+	kind.Kind = strings.TrimSuffix(kind.Kind, "List")
+	cache, err := c.cacheForKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.GetInformerForKind(gvk)
+}
+
+func (c *clusterScopedCache) Start(stopCh <-chan struct{}) error {
+	go func() {
+		_ = c.cluster.Start(stopCh)
+	}()
+
+	return c.Cache.Start(stopCh)
+}
+
+func (c *clusterScopedCache) WaitForCacheSync(stop <-chan struct{}) bool {
+	if !c.cluster.WaitForCacheSync(stop) {
+		return false
+	}
+
+	return c.Cache.WaitForCacheSync(stop)
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recordingCache records the objects read from the cache.
+type recordingCache struct {
+	informertest.FakeInformers
+	reads []runtime.Object
+}
+
+func (c *recordingCache) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	c.reads = append(c.reads, obj)
+	return nil
+}
+
+func (c *recordingCache) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	c.reads = append(c.reads, list)
+	return nil
+}
+
+var _ = Describe("Cluster Scoped Cache", func() {
+	ctx := context.TODO()
+	var namespaced, cluster *recordingCache
+	var c *clusterScopedCache
+
+	BeforeEach(func() {
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), apimeta.RESTScopeRoot)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), apimeta.RESTScopeNamespace)
+		namespaced = &recordingCache{FakeInformers: informertest.FakeInformers{Scheme: scheme.Scheme}}
+		cluster = &recordingCache{FakeInformers: informertest.FakeInformers{Scheme: scheme.Scheme}}
+		c = newClusterScopedCache(namespaced, cluster, scheme.Scheme, mapper)
+	})
+
+	It("Reads cluster scoped objects from the cluster cache", func() {
+		Expect(c.Get(ctx, types.NamespacedName{Name: "a"}, &corev1.Namespace{})).Should(Succeed())
+		Expect(c.List(ctx, &corev1.NamespaceList{})).Should(Succeed())
+		Expect(cluster.reads).To(HaveLen(2))
+		Expect(namespaced.reads).To(BeEmpty())
+	})
+
+	It("Reads namespaced objects from the namespaced cache", func() {
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "a", Name: "a"}, &corev1.Secret{})).Should(Succeed())
+		Expect(c.List(ctx, &corev1.SecretList{}, client.InNamespace("a"))).Should(Succeed())
+		Expect(namespaced.reads).To(HaveLen(2))
+		Expect(cluster.reads).To(BeEmpty())
+	})
+
+	It("Gets informers for cluster scoped objects from the cluster cache", func() {
+		_, err := c.GetInformer(&corev1.Namespace{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.InformersByGVK).To(HaveKey(corev1.SchemeGroupVersion.WithKind("Namespace")))
+		Expect(namespaced.InformersByGVK).NotTo(HaveKey(corev1.SchemeGroupVersion.WithKind("Namespace")))
+	})
+})
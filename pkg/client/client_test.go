@@ -0,0 +1,59 @@
+package client
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/client/clientset/versioned/fake"
+	"github.com/phillebaba/dela/pkg/client/informers/externalversions"
+)
+
+var _ = Describe("Client", func() {
+	const timeout = time.Second * 5
+	const interval = time.Millisecond * 100
+
+	It("Creates and gets Intents", func() {
+		client := fake.NewSimpleClientset()
+		intent := &delav1alpha1.Intent{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+			Spec:       delav1alpha1.IntentSpec{SecretName: "main"},
+		}
+
+		_, err := client.DelaV1alpha1().Intents(intent.Namespace).Create(intent)
+		Expect(err).ToNot(HaveOccurred())
+		result, err := client.DelaV1alpha1().Intents(intent.Namespace).Get(intent.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Spec.SecretName).To(Equal(intent.Spec.SecretName))
+
+		intents, err := client.DelaV1alpha1().Intents("other").List(metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(intents.Items).To(BeEmpty())
+	})
+
+	It("Lists cluster scoped resources from informers", func() {
+		quota := &delav1alpha1.RequestQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "main"},
+			Spec:       delav1alpha1.RequestQuotaSpec{MaxRequests: 1},
+		}
+		client := fake.NewSimpleClientset(quota)
+		factory := externalversions.NewSharedInformerFactory(client, 0)
+		lister := factory.Dela().V1alpha1().RequestQuotas().Lister()
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		factory.Start(stopCh)
+		factory.WaitForCacheSync(stopCh)
+
+		Eventually(func() ([]*delav1alpha1.RequestQuota, error) {
+			return lister.List(labels.Everything())
+		}, timeout, interval).Should(HaveLen(1))
+		result, err := lister.Get(quota.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Spec.MaxRequests).To(Equal(quota.Spec.MaxRequests))
+	})
+})
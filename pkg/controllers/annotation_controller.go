@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// AnnotationReconciler translates sharing annotations on Secrets into Intents and Requests.
+// A Secret annotated with the namespaces it is shared to gets an Intent, and a Secret stub
+// annotated with the Secret it is shared from gets a Request that adopts the stub.
+type AnnotationReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=intents,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=requests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+func (r *AnnotationReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, req.NamespacedName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The Request is not owned by the stub, so it has to be deleted when the stub is deleted
+			request := &delav1alpha1.Request{ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace}}
+			return ctrl.Result{}, r.deleteRequest(ctx, request, metav1.DeletePropagationBackground)
+		}
+		return ctrl.Result{}, err
+	}
+	if secret.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.reconcileIntent(ctx, secret); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileRequest(ctx, secret); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileIntent creates an Intent for a Secret with the share to annotation, or deletes it when the annotation is removed.
+func (r *AnnotationReconciler) reconcileIntent(ctx context.Context, secret *corev1.Secret) error {
+	intent := &delav1alpha1.Intent{ObjectMeta: metav1.ObjectMeta{Name: secret.Name, Namespace: secret.Namespace}}
+	value, ok := secret.Annotations[delav1alpha1.ShareToAnnotation]
+	if !ok {
+		return r.deleteControlled(ctx, secret, intent)
+	}
+
+	whitelist := splitAnnotationList(value)
+	if len(whitelist) == 0 {
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, "InvalidAnnotation", "Annotation %s does not list any namespaces", delav1alpha1.ShareToAnnotation)
+		return nil
+	}
+	for _, ns := range whitelist {
+		if _, err := regexp.Compile(ns); err != nil {
+			r.Recorder.Eventf(secret, corev1.EventTypeWarning, "InvalidAnnotation", "Annotation %s contains invalid namespace %q: %v", delav1alpha1.ShareToAnnotation, ns, err)
+			return nil
+		}
+	}
+
+	other, err := r.controlledByOther(ctx, secret, intent)
+	if err != nil {
+		return err
+	}
+	if other {
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, "IntentExists", "Intent %q already exists and is not managed by annotations", intent.Name)
+		return nil
+	}
+	_, err = ctrl.CreateOrUpdate(ctx, r, intent, func() error {
+		intent.Spec.SecretName = secret.Name
+		intent.Spec.NamespaceWhitelist = whitelist
+		return controllerutil.SetControllerReference(secret, intent, r.Scheme)
+	})
+	return err
+}
+
+// reconcileRequest creates a Request for a Secret stub with the share from annotation, or deletes it when the annotation is removed.
+func (r *AnnotationReconciler) reconcileRequest(ctx context.Context, secret *corev1.Secret) error {
+	request := &delav1alpha1.Request{ObjectMeta: metav1.ObjectMeta{Name: secret.Name, Namespace: secret.Namespace}}
+	value, ok := secret.Annotations[delav1alpha1.ShareFromAnnotation]
+	if !ok {
+		// Orphan the stub, as it is controlled by the Request once adopted
+		return r.deleteRequest(ctx, request, metav1.DeletePropagationOrphan)
+	}
+
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, "InvalidAnnotation", "Annotation %s has to be in the format namespace/name", delav1alpha1.ShareFromAnnotation)
+		return nil
+	}
+
+	if err := r.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, request); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if request.UID != "" && !managedRequest(request, secret) {
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, "RequestExists", "Request %q already exists and is not managed by annotations", request.Name)
+		return nil
+	}
+	_, err := ctrl.CreateOrUpdate(ctx, r, request, func() error {
+		request.Spec.IntentRef = delav1alpha1.IntentReference{Namespace: parts[0], Name: parts[1]}
+		request.Spec.SecretObjectMeta = metav1.ObjectMeta{Name: secret.Name}
+		// The stub is created by the consumer, so it has to be adopted to be filled with the shared data
+		request.Spec.AdoptionPolicy = delav1alpha1.AdoptionPolicyAlways
+		if request.Labels == nil {
+			request.Labels = map[string]string{}
+		}
+		request.Labels[delav1alpha1.ShareFromLabel] = "true"
+		// Requests created before the label was introduced are controlled by the stub, which is an ownership cycle
+		ownerRefs := []metav1.OwnerReference{}
+		for _, ref := range request.OwnerReferences {
+			if ref.UID != secret.UID {
+				ownerRefs = append(ownerRefs, ref)
+			}
+		}
+		request.OwnerReferences = ownerRefs
+		return nil
+	})
+	return err
+}
+
+// managedRequest checks if the Request was created for the Secret stub.
+func managedRequest(request *delav1alpha1.Request, secret *corev1.Secret) bool {
+	return request.Labels[delav1alpha1.ShareFromLabel] == "true" || metav1.IsControlledBy(request, secret)
+}
+
+// deleteRequest deletes the Request if it was created for a Secret stub with the same name.
+func (r *AnnotationReconciler) deleteRequest(ctx context.Context, request *delav1alpha1.Request, propagation metav1.DeletionPropagation) error {
+	if err := r.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, request); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if request.DeletionTimestamp != nil {
+		return nil
+	}
+	stub := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: request.Name, Namespace: request.Namespace}}
+	if err := r.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, stub); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if !managedRequest(request, stub) {
+		return nil
+	}
+	if err := r.Delete(ctx, request, client.PropagationPolicy(propagation)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("could not delete %s: %v", request.Name, err)
+	}
+
+	return nil
+}
+
+// controlledByOther checks if the object exists without being controlled by the Secret.
+func (r *AnnotationReconciler) controlledByOther(ctx context.Context, secret *corev1.Secret, obj runtime.Object) (bool, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false, err
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: accessor.GetName(), Namespace: accessor.GetNamespace()}, obj); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return !metav1.IsControlledBy(accessor, secret), nil
+}
+
+// deleteControlled deletes the object if it is controlled by the Secret.
+// Objects controlled by the deleted object are orphaned, so that removing an annotation never deletes a Secret.
+func (r *AnnotationReconciler) deleteControlled(ctx context.Context, secret *corev1.Secret, obj runtime.Object) error {
+	other, err := r.controlledByOther(ctx, secret, obj)
+	if err != nil || other {
+		return err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	if accessor.GetUID() == "" {
+		return nil
+	}
+	if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationOrphan)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("could not delete %s: %v", accessor.GetName(), err)
+	}
+
+	return nil
+}
+
+func (r *AnnotationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	stubMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			if a.Meta.GetLabels()[delav1alpha1.ShareFromLabel] != "true" {
+				return []reconcile.Request{}
+			}
+			return []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: a.Meta.GetName(), Namespace: a.Meta.GetNamespace()}},
+			}
+		})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}).
+		Owns(&delav1alpha1.Intent{}).
+		Watches(
+			&source.Kind{Type: &delav1alpha1.Request{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: stubMapFn},
+		).
+		Complete(r)
+}
+
+// splitAnnotationList splits a comma separated annotation value, ignoring empty values.
+func splitAnnotationList(value string) []string {
+	list := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
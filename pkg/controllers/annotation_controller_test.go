@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+var _ = Describe("Annotation Controller", func() {
+	const timeout = time.Second * 30
+	const interval = time.Second * 1
+
+	ctx := context.TODO()
+	source := SetupTestNamespace(ctx)
+	dest := SetupTestNamespace(ctx)
+
+	It("Copies a Secret into an annotated stub", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "main",
+				Namespace:   source.Name,
+				Annotations: map[string]string{delav1alpha1.ShareToAnnotation: "other, " + dest.Name},
+			},
+			Data: map[string][]byte{"foo": []byte("bar")},
+		}
+		stub := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "copy",
+				Namespace:   dest.Name,
+				Annotations: map[string]string{delav1alpha1.ShareFromAnnotation: source.Name + "/main"},
+			},
+		}
+
+		By("Creating an annotated Secret and stub")
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, stub)).Should(Succeed())
+		Eventually(func() *delav1alpha1.Intent {
+			intent := &delav1alpha1.Intent{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, intent)
+			return intent
+		}, timeout, interval).Should(
+			WithTransform(func(e *delav1alpha1.Intent) []string { return e.Spec.NamespaceWhitelist }, Equal([]string{"other", dest.Name})),
+		)
+		Eventually(func() []byte {
+			copy := &corev1.Secret{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, copy)
+			return copy.Data["foo"]
+		}, timeout, interval).Should(Equal([]byte("bar")))
+
+		By("Removing the annotation from the stub")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, stub)).Should(Succeed())
+		delete(stub.Annotations, delav1alpha1.ShareFromAnnotation)
+		Expect(k8sClient.Update(ctx, stub)).Should(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, &delav1alpha1.Request{})
+		}, timeout, interval).ShouldNot(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, &corev1.Secret{})).Should(Succeed())
+	})
+
+	It("Deletes the Request when the stub is deleted", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "main",
+				Namespace:   source.Name,
+				Annotations: map[string]string{delav1alpha1.ShareToAnnotation: dest.Name},
+			},
+			Data: map[string][]byte{"foo": []byte("bar")},
+		}
+		stub := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "copy",
+				Namespace:   dest.Name,
+				Annotations: map[string]string{delav1alpha1.ShareFromAnnotation: source.Name + "/main"},
+			},
+		}
+
+		By("Creating an annotated Secret and stub")
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, stub)).Should(Succeed())
+		request := &delav1alpha1.Request{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, request)
+		}, timeout, interval).Should(Succeed())
+		Expect(request.Labels).Should(HaveKeyWithValue(delav1alpha1.ShareFromLabel, "true"))
+		Expect(request.OwnerReferences).Should(BeEmpty())
+		Eventually(func() []byte {
+			copy := &corev1.Secret{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, copy)
+			return copy.Data["foo"]
+		}, timeout, interval).Should(Equal([]byte("bar")))
+
+		By("Deleting the stub")
+		Expect(k8sClient.Delete(ctx, stub)).Should(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, &delav1alpha1.Request{})
+		}, timeout, interval).ShouldNot(Succeed())
+	})
+
+	It("Does not share to namespaces that are not annotated", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "main",
+				Namespace:   source.Name,
+				Annotations: map[string]string{delav1alpha1.ShareToAnnotation: "^other$"},
+			},
+			Data: map[string][]byte{"foo": []byte("bar")},
+		}
+		stub := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "copy",
+				Namespace:   dest.Name,
+				Annotations: map[string]string{delav1alpha1.ShareFromAnnotation: source.Name + "/main"},
+			},
+		}
+
+		By("Creating an annotated Secret and stub")
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, stub)).Should(Succeed())
+		Eventually(func() delav1alpha1.RequestState {
+			request := &delav1alpha1.Request{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, request)
+			return request.Status.State
+		}, timeout, interval).Should(Equal(delav1alpha1.RequestStateError))
+		Consistently(func() map[string][]byte {
+			copy := &corev1.Secret{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: stub.Name, Namespace: stub.Namespace}, copy)
+			return copy.Data
+		}, time.Second*5, interval).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
+)
+
+// auditRequest writes an audit record for a decision made for the Request about the named Secret copy.
+// The source Secret is optional, and is used to record the version and content of the shared data.
+func (r *RequestReconciler) auditRequest(request *delav1alpha1.Request, action audit.Action, reason string, secretName string, secret *corev1.Secret) {
+	if r.Audit == nil {
+		return
+	}
+
+	record := audit.Record{
+		Action:               action,
+		Reason:               reason,
+		Intent:               types.NamespacedName{Name: request.Spec.IntentRef.Name, Namespace: request.Spec.IntentRef.Namespace}.String(),
+		Request:              types.NamespacedName{Name: request.Name, Namespace: request.Namespace}.String(),
+		SourceNamespace:      request.Spec.IntentRef.Namespace,
+		DestinationNamespace: request.Namespace,
+		Secret:               secretName,
+		DryRun:               r.DryRun,
+	}
+	if secret != nil {
+		record.SourceResourceVersion = secret.ResourceVersion
+		record.ContentHash = audit.ContentHash(secret.Data)
+	}
+	if err := r.Audit.Write(record); err != nil {
+		r.Log.Error(err, "Could not write audit record", "request", record.Request, "action", action)
+	}
+}
+
+// auditRequestGranted writes an audit record for a Request that was granted access to the Intent, once the Secret copy is written.
+// Nothing is recorded if access was already granted, so that reconciles without a change are not recorded again.
+func (r *RequestReconciler) auditRequestGranted(request *delav1alpha1.Request, previousState delav1alpha1.RequestState, secret *corev1.Secret) {
+	condition := delav1alpha1.GetCondition(request.Status.Conditions, delav1alpha1.RequestConditionDenied)
+	if condition != nil && condition.Status == corev1.ConditionFalse {
+		return
+	}
+	request.Status.Conditions = delav1alpha1.SetCondition(request.Status.Conditions, delav1alpha1.Condition{
+		Type:   delav1alpha1.RequestConditionDenied,
+		Status: corev1.ConditionFalse,
+		Reason: "Granted",
+	})
+	// Requests fulfilled before the condition was introduced were already recorded as granted
+	if condition == nil && previousState == delav1alpha1.RequestStateReady {
+		return
+	}
+	r.auditRequest(request, audit.ActionGranted, "", request.Spec.SecretObjectMeta.Name, secret)
+}
+
+// auditRequestDenied writes an audit record for a denied Request.
+// The denial is recorded as a revocation if the Request was previously fulfilled, and nothing is recorded if neither the state
+// nor the reason of the last denial has changed.
+func (r *RequestReconciler) auditRequestDenied(request *delav1alpha1.Request, previousState delav1alpha1.RequestState, reason string) {
+	condition := delav1alpha1.GetCondition(request.Status.Conditions, delav1alpha1.RequestConditionDenied)
+	if previousState == request.Status.State && condition != nil && condition.Status == corev1.ConditionTrue && condition.Reason == reason {
+		return
+	}
+	request.Status.Conditions = delav1alpha1.SetCondition(request.Status.Conditions, delav1alpha1.Condition{
+		Type:   delav1alpha1.RequestConditionDenied,
+		Status: corev1.ConditionTrue,
+		Reason: reason,
+	})
+	action := audit.ActionDenied
+	if previousState == delav1alpha1.RequestStateReady {
+		action = audit.ActionRevoked
+	}
+	r.auditRequest(request, action, reason, request.Spec.SecretObjectMeta.Name, nil)
+}
+
+// auditIntent writes an audit record for a decision made for the Intent.
+func (r *IntentReconciler) auditIntent(intent *delav1alpha1.Intent, action audit.Action, reason string, secret *corev1.Secret) {
+	if r.Audit == nil {
+		return
+	}
+
+	record := audit.Record{
+		Action:          action,
+		Reason:          reason,
+		Intent:          types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}.String(),
+		SourceNamespace: intent.Namespace,
+		Secret:          intent.Spec.SecretName,
+		DryRun:          r.DryRun,
+	}
+	if secret != nil {
+		record.SourceResourceVersion = secret.ResourceVersion
+		record.ContentHash = audit.ContentHash(secret.Data)
+	}
+	if err := r.Audit.Write(record); err != nil {
+		r.Log.Error(err, "Could not write audit record", "intent", record.Intent, "action", action)
+	}
+}
+
+// auditIntentGranted writes an audit record for an Intent that was allowed to share its Secret.
+// Nothing is recorded if the Intent was already Ready.
+func (r *IntentReconciler) auditIntentGranted(intent *delav1alpha1.Intent, previousState delav1alpha1.IntentState, secret *corev1.Secret) {
+	if previousState == delav1alpha1.IntentStateReady {
+		return
+	}
+	r.auditIntent(intent, audit.ActionGranted, "", secret)
+}
+
+// auditIntentDenied writes an audit record for an Intent that is not allowed to share its Secret.
+// The denial is recorded as a revocation if the Intent was previously Ready, and nothing is recorded if the state is unchanged.
+func (r *IntentReconciler) auditIntentDenied(intent *delav1alpha1.Intent, previousState delav1alpha1.IntentState, reason string, secret *corev1.Secret) {
+	if previousState == intent.Status.State {
+		return
+	}
+	action := audit.ActionDenied
+	if previousState == delav1alpha1.IntentStateReady {
+		action = audit.ActionRevoked
+	}
+	r.auditIntent(intent, action, reason, secret)
+}
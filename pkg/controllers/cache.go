@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// secretFallbackClient reads Secrets that are not found in the cache from the API server.
+// When the cache is limited to Secrets with the managed label, shared Secrets that have not been labeled yet
+// and existing Secrets that are adopted are only found this way.
+type secretFallbackClient struct {
+	client.Client
+	APIReader client.Reader
+}
+
+func (c *secretFallbackClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	err := c.Client.Get(ctx, key, obj)
+	if _, ok := obj.(*corev1.Secret); ok && apierrors.IsNotFound(err) {
+		return c.APIReader.Get(ctx, key, obj)
+	}
+
+	return err
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	delacache "github.com/phillebaba/dela/pkg/cache"
+)
+
+var _ = Describe("Multi namespace manager", func() {
+	const timeout = time.Second * 30
+	const interval = time.Second * 1
+
+	ctx := context.TODO()
+	source := SetupTestNamespace(ctx)
+	dest := SetupTestNamespace(ctx)
+	var stop chan struct{}
+	var c client.Client
+
+	startManager := func(namespaces []string) {
+		mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+			Scheme:             scheme.Scheme,
+			MetricsBindAddress: "0",
+			NewCache:           delacache.MultiNamespaced(namespaces),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		stop = make(chan struct{})
+		go func() {
+			_ = mgr.Start(stop)
+		}()
+		Expect(mgr.GetCache().WaitForCacheSync(stop)).To(BeTrue())
+		c = mgr.GetClient()
+	}
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("Reads cluster scoped objects when restricted to a single namespace", func() {
+		startManager([]string{source.Name})
+
+		By("Getting another Namespace")
+		Eventually(func() error {
+			return c.Get(ctx, types.NamespacedName{Name: dest.Name}, &corev1.Namespace{})
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("Reads cluster scoped objects", func() {
+		startManager([]string{source.Name, dest.Name})
+		policy := &delav1alpha1.SharePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: source.Name},
+			Spec: delav1alpha1.SharePolicySpec{
+				IntentNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"share": "true"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+		defer func() {
+			Expect(k8sClient.Delete(ctx, policy)).Should(Succeed())
+		}()
+
+		By("Getting a Namespace")
+		Eventually(func() error {
+			return c.Get(ctx, types.NamespacedName{Name: source.Name}, &corev1.Namespace{})
+		}, timeout, interval).Should(Succeed())
+
+		By("Listing Namespaces and SharePolicies once")
+		namespaces := &corev1.NamespaceList{}
+		Expect(c.List(ctx, namespaces)).Should(Succeed())
+		count := 0
+		for _, ns := range namespaces.Items {
+			if ns.Name == source.Name {
+				count++
+			}
+		}
+		Expect(count).To(Equal(1))
+		Eventually(func() int {
+			policies := &delav1alpha1.SharePolicyList{}
+			_ = c.List(ctx, policies)
+			count := 0
+			for _, p := range policies.Items {
+				if p.Name == policy.Name {
+					count++
+				}
+			}
+			return count
+		}, timeout, interval).Should(Equal(1))
+
+		By("Evaluating SharePolicies of an Intent")
+		intent := &delav1alpha1.Intent{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: source.Name}}
+		message, err := IntentPolicyViolation(ctx, c, intent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(ContainSubstring(policy.Name))
+	})
+})
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// IntentCatalogReconciler maintains an IntentCatalog for each Namespace
+type IntentCatalogReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=intentcatalogs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=intents,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=sharepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+func (r *IntentCatalogReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("namespace", req.Name)
+
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, req.NamespacedName, namespace); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if namespace.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	// List Intents that can be requested from the Namespace
+	var intents delav1alpha1.IntentList
+	if err := r.List(ctx, &intents); err != nil {
+		return ctrl.Result{}, err
+	}
+	entries := []delav1alpha1.IntentCatalogEntry{}
+	for _, intent := range intents.Items {
+		if intent.Spec.Visibility == delav1alpha1.VisibilityPrivate || intent.Status.State != delav1alpha1.IntentStateReady {
+			continue
+		}
+
+		matches, err := matchesIntentNamespaces(&intent, namespace)
+		if err != nil {
+			log.Error(err, "Invalid namespace whitelist", "intent", types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace})
+			continue
+		}
+		if !matches {
+			continue
+		}
+
+		intentRef := delav1alpha1.IntentReference{Name: intent.Name, Namespace: intent.Namespace}
+		request := &delav1alpha1.Request{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name},
+			Spec:       delav1alpha1.RequestSpec{IntentRef: intentRef},
+		}
+		violation, err := RequestPolicyViolation(ctx, r, request)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if violation != "" {
+			continue
+		}
+
+		entries = append(entries, delav1alpha1.IntentCatalogEntry{
+			IntentRef:   intentRef,
+			Description: intent.Spec.Description,
+			Owner:       intent.Spec.Owner,
+			SecretType:  intent.Status.SecretType,
+			Keys:        intent.Status.Keys,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].IntentRef.Namespace != entries[j].IntentRef.Namespace {
+			return entries[i].IntentRef.Namespace < entries[j].IntentRef.Namespace
+		}
+		return entries[i].IntentRef.Name < entries[j].IntentRef.Name
+	})
+
+	catalog := &delav1alpha1.IntentCatalog{ObjectMeta: metav1.ObjectMeta{Name: namespace.Name}}
+	if _, err := ctrl.CreateOrUpdate(ctx, r, catalog, func() error {
+		catalog.Entries = entries
+		return controllerutil.SetControllerReference(namespace, catalog, r.Scheme)
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// catalogEntryChanged checks if the update of the Intent can change its entry in IntentCatalogs.
+func catalogEntryChanged(evt event.UpdateEvent) bool {
+	oldIntent, ok := evt.ObjectOld.(*delav1alpha1.Intent)
+	if !ok {
+		return true
+	}
+	newIntent, ok := evt.ObjectNew.(*delav1alpha1.Intent)
+	if !ok {
+		return true
+	}
+
+	return oldIntent.Generation != newIntent.Generation ||
+		oldIntent.Status.State != newIntent.Status.State ||
+		oldIntent.Status.SecretType != newIntent.Status.SecretType ||
+		!equality.Semantic.DeepEqual(oldIntent.Status.Keys, newIntent.Status.Keys)
+}
+
+// intentCatalogHandler enqueues the Namespaces whose IntentCatalog can list the Intent.
+// Updates that do not change the catalog entry, like most status updates, are ignored.
+type intentCatalogHandler struct {
+	handler.EnqueueRequestsFromMapFunc
+}
+
+func (h *intentCatalogHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if !catalogEntryChanged(evt) {
+		return
+	}
+	h.EnqueueRequestsFromMapFunc.Update(evt, q)
+}
+
+func (r *IntentCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// SharePolicies can affect the catalog of any Namespace
+	allNamespacesMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			ctx := context.Background()
+
+			var namespaces corev1.NamespaceList
+			if err := r.List(ctx, &namespaces); err != nil {
+				return []reconcile.Request{}
+			}
+			reconcileReq := []reconcile.Request{}
+			for _, namespace := range namespaces.Items {
+				reconcileReq = append(reconcileReq, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name: namespace.Name,
+				}})
+			}
+
+			return reconcileReq
+		},
+	)
+
+	// Intents only affect the catalog of whitelisted Namespaces, the old and new whitelist are both mapped on update
+	whitelistMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			ctx := context.Background()
+			intent, ok := a.Object.(*delav1alpha1.Intent)
+			if !ok {
+				return []reconcile.Request{}
+			}
+
+			var namespaces corev1.NamespaceList
+			if err := r.List(ctx, &namespaces); err != nil {
+				return []reconcile.Request{}
+			}
+			reconcileReq := []reconcile.Request{}
+			for _, namespace := range namespaces.Items {
+				matches, err := matchesIntentNamespaces(intent, &namespace)
+				if err != nil || !matches {
+					continue
+				}
+				reconcileReq = append(reconcileReq, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name: namespace.Name,
+				}})
+			}
+
+			return reconcileReq
+		},
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Namespace{}).
+		Owns(&delav1alpha1.IntentCatalog{}).
+		Watches(
+			&source.Kind{Type: &delav1alpha1.Intent{}},
+			&intentCatalogHandler{EnqueueRequestsFromMapFunc: handler.EnqueueRequestsFromMapFunc{ToRequests: whitelistMapFn}},
+		).
+		Watches(
+			&source.Kind{Type: &delav1alpha1.SharePolicy{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: allNamespacesMapFn},
+		).
+		Complete(r)
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+var _ = Describe("IntentCatalog Controller", func() {
+	const timeout = time.Second * 30
+	const interval = time.Second * 1
+
+	ctx := context.TODO()
+	source := SetupTestNamespace(ctx)
+	dest := SetupTestNamespace(ctx)
+
+	It("Lists whitelisted Intents in the IntentCatalog", func() {
+		secret, intent, _ := baseResources(source, dest)
+		intent.Spec.NamespaceWhitelist = []string{dest.Name}
+		intent.Spec.Description = "Credentials for the main database"
+		intent.Spec.Owner = "team-main@example.com"
+
+		By("Creating a Secret and Intent")
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+		Eventually(func() []delav1alpha1.IntentCatalogEntry {
+			catalog := &delav1alpha1.IntentCatalog{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: dest.Name}, catalog)
+			return catalog.Entries
+		}, timeout, interval).Should(ContainElement(delav1alpha1.IntentCatalogEntry{
+			IntentRef:   delav1alpha1.IntentReference{Name: intent.Name, Namespace: intent.Namespace},
+			Description: intent.Spec.Description,
+			Owner:       intent.Spec.Owner,
+			SecretType:  "Opaque",
+			Keys:        []string{"foo"},
+		}))
+
+		By("Checking the IntentCatalog of a Namespace that is not whitelisted")
+		Consistently(func() []delav1alpha1.IntentCatalogEntry {
+			catalog := &delav1alpha1.IntentCatalog{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: source.Name}, catalog)
+			return catalog.Entries
+		}, time.Second*5, interval).Should(BeEmpty())
+
+		By("Making the Intent private")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}, intent)).Should(Succeed())
+		intent.Spec.Visibility = delav1alpha1.VisibilityPrivate
+		Expect(k8sClient.Update(ctx, intent)).Should(Succeed())
+		Eventually(func() []delav1alpha1.IntentCatalogEntry {
+			catalog := &delav1alpha1.IntentCatalog{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: dest.Name}, catalog)
+			return catalog.Entries
+		}, timeout, interval).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// defaultCertificateWarningWindow is the time before expiry when warnings start if the Intent does not set it.
+const defaultCertificateWarningWindow = 30 * 24 * time.Hour
+
+// parseCertificates parses all PEM encoded certificates in the value.
+// Returns an error if the value contains other PEM blocks or a certificate can not be parsed.
+func parseCertificates(value []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	for rest := value; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+// secretCertificates describes the first certificate of each key that contains PEM encoded certificates, ordered by key.
+// Keys that do not contain certificates, or that can not be parsed, are skipped.
+func secretCertificates(data map[string][]byte) []delav1alpha1.CertificateInfo {
+	infos := []delav1alpha1.CertificateInfo{}
+	for key, value := range data {
+		certs, err := parseCertificates(value)
+		if err != nil || len(certs) == 0 {
+			continue
+		}
+		cert := certs[0]
+		sans := append([]string{}, cert.DNSNames...)
+		for _, ip := range cert.IPAddresses {
+			sans = append(sans, ip.String())
+		}
+		sans = append(sans, cert.EmailAddresses...)
+		for _, uri := range cert.URIs {
+			sans = append(sans, uri.String())
+		}
+		infos = append(infos, delav1alpha1.CertificateInfo{
+			Key:             key,
+			Subject:         cert.Subject.String(),
+			Issuer:          cert.Issuer.String(),
+			SubjectAltNames: sans,
+			NotBefore:       metav1.NewTime(cert.NotBefore),
+			NotAfter:        metav1.NewTime(cert.NotAfter),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Key < infos[j].Key
+	})
+
+	return infos
+}
+
+// certificateWarningWindow returns the time before expiry when warnings start for the Intent.
+func certificateWarningWindow(intent *delav1alpha1.Intent) time.Duration {
+	if intent.Spec.CertificateExpiry == nil || intent.Spec.CertificateExpiry.WarningWindow == nil {
+		return defaultCertificateWarningWindow
+	}
+
+	return intent.Spec.CertificateExpiry.WarningWindow.Duration
+}
+
+// reconcileCertificates publishes the certificates of the shared Secret in the status of the Intent, and warns about certificates
+// that expire within the warning window. Warnings are only raised when the expiry condition changes. Returns if any certificate
+// has expired, and the duration after which the Intent should be reconciled again for the next warning to be raised.
+func (r *IntentReconciler) reconcileCertificates(intent *delav1alpha1.Intent, secret *corev1.Secret) (bool, time.Duration) {
+	now := time.Now()
+	window := certificateWarningWindow(intent)
+	intent.Status.Certificates = secretCertificates(secret.Data)
+	setCertificateMetrics(intent.Namespace, intent.Name, intent.Status.Certificates, window)
+
+	expired := false
+	requeueAfter := time.Duration(0)
+	reason, message := "", ""
+	for _, cert := range intent.Status.Certificates {
+		notAfter := cert.NotAfter.Time
+		var next time.Duration
+		switch {
+		case !now.Before(notAfter):
+			if !expired {
+				reason, message = "Expired", fmt.Sprintf("Certificate in key %q expired at %s", cert.Key, notAfter.Format(time.RFC3339))
+			}
+			expired = true
+			continue
+		case now.Add(window).Before(notAfter):
+			next = notAfter.Add(-window).Sub(now)
+		default:
+			if reason == "" {
+				reason, message = "Expiring", fmt.Sprintf("Certificate in key %q expires at %s", cert.Key, notAfter.Format(time.RFC3339))
+			}
+			// Reconcile again just after the certificate has expired
+			next = notAfter.Sub(now) + time.Second
+		}
+		if requeueAfter == 0 || next < requeueAfter {
+			requeueAfter = next
+		}
+	}
+
+	if reason == "" {
+		intent.Status.Conditions = delav1alpha1.RemoveCondition(intent.Status.Conditions, delav1alpha1.ConditionCertificateExpiring)
+		return expired, requeueAfter
+	}
+
+	// Only warn once until the expiring certificate changes
+	condition := delav1alpha1.GetCondition(intent.Status.Conditions, delav1alpha1.ConditionCertificateExpiring)
+	if condition == nil || condition.Reason != reason || condition.Message != message {
+		eventReason := "CertificateExpiring"
+		if reason == "Expired" {
+			eventReason = "CertificateExpired"
+		}
+		r.Recorder.Event(intent, corev1.EventTypeWarning, eventReason, message)
+	}
+	intent.Status.Conditions = delav1alpha1.SetCondition(intent.Status.Conditions, delav1alpha1.Condition{
+		Type:    delav1alpha1.ConditionCertificateExpiring,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+
+	return expired, requeueAfter
+}
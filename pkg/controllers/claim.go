@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// ClaimConflict checks if the destination Secret name of the Request is claimed by another Request in the same namespace.
+// A Request that already controls the Secret holds the claim, otherwise the claim belongs to the Request created first.
+// Returns a message naming the Request holding the claim, or an empty string if the Request holds or can take the claim.
+func ClaimConflict(ctx context.Context, c client.Client, request *delav1alpha1.Request) (string, error) {
+	secretName := request.Spec.SecretObjectMeta.Name
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Name: secretName, Namespace: request.Namespace}, secret)
+	if client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+	if err == nil {
+		owner := metav1.GetControllerOf(secret)
+		if owner != nil && owner.APIVersion == apiGVStr && owner.Kind == "Request" {
+			if owner.Name == request.Name {
+				return "", nil
+			}
+
+			// Claim is released when the owner is deleted, even if the Secret has not been garbage collected yet
+			ownerRequest := &delav1alpha1.Request{}
+			err := c.Get(ctx, types.NamespacedName{Name: owner.Name, Namespace: request.Namespace}, ownerRequest)
+			if client.IgnoreNotFound(err) != nil {
+				return "", err
+			}
+			if err == nil && ownerRequest.UID == owner.UID && ownerRequest.DeletionTimestamp == nil {
+				return claimMessage(secretName, owner.Name), nil
+			}
+		}
+	}
+
+	owner, err := claimingRequest(ctx, c, request, secretName, false)
+	if err != nil {
+		return "", err
+	}
+
+	// Versioned copies of a Request with versioning are named after its Secret copy with the version appended
+	if match := versionedNameRegex.FindStringSubmatch(secretName); owner == "" && match != nil {
+		owner, err = claimingRequest(ctx, c, request, match[1], true)
+		if err != nil {
+			return "", err
+		}
+	}
+	if owner == "" {
+		return "", nil
+	}
+
+	return claimMessage(secretName, owner), nil
+}
+
+// versionClaimConflict checks if the name of a versioned copy of the Request is the destination Secret of another Request
+// created before it in the same namespace. Returns a message naming the Request holding the claim.
+func versionClaimConflict(ctx context.Context, c client.Client, request *delav1alpha1.Request, secretName string) (string, error) {
+	owner, err := claimingRequest(ctx, c, request, secretName, false)
+	if err != nil || owner == "" {
+		return "", err
+	}
+
+	return claimMessage(secretName, owner), nil
+}
+
+// versionedNameRegex matches the names of versioned Secret copies, capturing the name of the Secret copy.
+var versionedNameRegex = regexp.MustCompile(fmt.Sprintf("^(.+)-[0-9a-f]{%d}$", versionLength))
+
+// claimingRequest returns the name of a Request created before the given Request in the same namespace with the destination Secret name,
+// optionally only counting Requests with versioning enabled. Returns an empty string if there is no such Request.
+func claimingRequest(ctx context.Context, c client.Client, request *delav1alpha1.Request, secretName string, versioned bool) (string, error) {
+	var requests delav1alpha1.RequestList
+	if err := c.List(ctx, &requests, client.InNamespace(request.Namespace), client.MatchingFields{secretNameKey: secretName}); err != nil {
+		return "", err
+	}
+	for _, other := range requests.Items {
+		if other.Name == request.Name || other.DeletionTimestamp != nil || (versioned && other.Spec.Versioning == nil) {
+			continue
+		}
+		if requestBefore(&other, request) {
+			return other.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+func claimMessage(secretName, owner string) string {
+	return fmt.Sprintf("Secret %q is claimed by Request %q", secretName, owner)
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// contractViolation checks the data against the content contract of the Intent.
+// Returns the reason of the first violation and a message listing all violations, or empty strings if the data fulfills the contract.
+func contractViolation(intent *delav1alpha1.Intent, data map[string][]byte) (string, string) {
+	contract := intent.Spec.Contract
+	if contract == nil {
+		return "", ""
+	}
+
+	reason := ""
+	violations := []string{}
+	check := func(key delav1alpha1.KeyContract, required bool) {
+		value, ok := data[key.Name]
+		if !ok {
+			if required {
+				if reason == "" {
+					reason = "MissingKey"
+				}
+				violations = append(violations, fmt.Sprintf("required key %q is missing", key.Name))
+			}
+			return
+		}
+		if err := validateKeyFormat(key.Format, value); err != nil {
+			if reason == "" {
+				reason = "InvalidFormat"
+			}
+			violations = append(violations, fmt.Sprintf("key %q is not %s: %v", key.Name, key.Format, err))
+		}
+	}
+	for _, key := range contract.Required {
+		check(key, true)
+	}
+	for _, key := range contract.Optional {
+		check(key, false)
+	}
+
+	return reason, strings.Join(violations, "; ")
+}
+
+// validateKeyFormat checks that the value has the format, any value is valid if the format is empty.
+func validateKeyFormat(format delav1alpha1.KeyFormat, value []byte) error {
+	switch format {
+	case "":
+		return nil
+	case delav1alpha1.KeyFormatNonEmpty:
+		if len(value) == 0 {
+			return fmt.Errorf("value is empty")
+		}
+	case delav1alpha1.KeyFormatPEMCertificate:
+		certs, err := parseCertificates(value)
+		if err != nil {
+			return err
+		}
+		if len(certs) == 0 {
+			return fmt.Errorf("no PEM encoded certificate found")
+		}
+	case delav1alpha1.KeyFormatPEMPrivateKey:
+		block, _ := pem.Decode(value)
+		if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			return fmt.Errorf("no PEM encoded private key found")
+		}
+		if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+		if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+		if _, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+		return fmt.Errorf("could not parse private key")
+	case delav1alpha1.KeyFormatJSON:
+		if !json.Valid(value) {
+			return fmt.Errorf("invalid JSON")
+		}
+	case delav1alpha1.KeyFormatURL:
+		u, err := url.Parse(string(value))
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("URL has to be absolute")
+		}
+	default:
+		return fmt.Errorf("unknown format")
+	}
+
+	return nil
+}
+
+// setContractViolationCondition sets the contract violation condition with the reason and message of the violation.
+// The condition is removed if there is no violation.
+func setContractViolationCondition(conditions []delav1alpha1.Condition, reason, message string) []delav1alpha1.Condition {
+	if reason == "" {
+		return delav1alpha1.RemoveCondition(conditions, delav1alpha1.ConditionContractViolation)
+	}
+
+	return delav1alpha1.SetCondition(conditions, delav1alpha1.Condition{
+		Type:    delav1alpha1.ConditionContractViolation,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// reconcileDrift reports drift between the Secret copy and the source Secret according to the drift policy of the Request.
+// Returns if the Secret copy should be restored, and the duration after which the Request should be reconciled again.
+func (r *RequestReconciler) reconcileDrift(request *delav1alpha1.Request, secretCopy *corev1.Secret, secret *corev1.Secret) (bool, time.Duration) {
+	keys := driftedKeys(secretCopy, secret)
+	if request.Spec.DriftPolicy == delav1alpha1.DriftPolicyIgnore {
+		request.Status.Conditions = delav1alpha1.SetCondition(request.Status.Conditions, delav1alpha1.Condition{
+			Type:    delav1alpha1.RequestConditionDrifted,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Ignored",
+			Message: "Drift detection is disabled by the drift policy",
+		})
+		return len(keys) == 0, 0
+	}
+	if len(keys) == 0 {
+		request.Status.Conditions = delav1alpha1.SetCondition(request.Status.Conditions, delav1alpha1.Condition{
+			Type:   delav1alpha1.RequestConditionDrifted,
+			Status: corev1.ConditionFalse,
+			Reason: "InSync",
+		})
+		return true, 0
+	}
+
+	reason, repair, requeueAfter := "Repaired", true, time.Duration(0)
+	if until, ok := divergeUntil(secretCopy); ok && time.Now().Before(until) {
+		reason, repair, requeueAfter = "Diverged", false, time.Until(until)
+	} else if request.Spec.DriftPolicy == delav1alpha1.DriftPolicyReport {
+		reason, repair = "Reported", false
+	}
+
+	// Only report drift once until the drifted keys change
+	message := fmt.Sprintf("Secret copy %q differs from source in keys: %s", secretCopy.Name, strings.Join(keys, ", "))
+	condition := delav1alpha1.GetCondition(request.Status.Conditions, delav1alpha1.RequestConditionDrifted)
+	if condition == nil || condition.Status != corev1.ConditionTrue || condition.Message != message {
+		requestDriftTotal.WithLabelValues(request.Namespace, request.Name).Inc()
+		r.Recorder.Event(request, corev1.EventTypeWarning, "Drifted", message)
+	}
+	request.Status.Conditions = delav1alpha1.SetCondition(request.Status.Conditions, delav1alpha1.Condition{
+		Type:    delav1alpha1.RequestConditionDrifted,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+
+	return repair, requeueAfter
+}
+
+// driftedKeys returns the names of the keys that differ between the Secret copy and the source Secret.
+// Differences are only drift if the data of the copy was changed after it was written, otherwise they are caused by a change to the source.
+// A copy without the data version annotation, or with one that does not match its data, is compared to the source.
+func driftedKeys(secretCopy *corev1.Secret, secret *corev1.Secret) []string {
+	if secretCopy.Annotations[delav1alpha1.DataVersionAnnotation] == dataVersion(secretCopy.Data) {
+		return nil
+	}
+
+	keys := []string{}
+	for k, v := range secret.Data {
+		if copyV, ok := secretCopy.Data[k]; !ok || !bytes.Equal(v, copyV) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range secretCopy.Data {
+		if _, ok := secret.Data[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// divergeUntil returns the time until which the Secret copy is allowed to diverge from the source.
+func divergeUntil(secretCopy *corev1.Secret) (time.Time, bool) {
+	value, ok := secretCopy.Annotations[delav1alpha1.DivergeUntilAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return until, true
+}
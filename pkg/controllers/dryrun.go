@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// dryRunClient performs all writes as server side dry runs so that they are validated but never persisted.
+// Status writes are not affected, as they are used to report the result of the dry run.
+type dryRunClient struct {
+	client.Client
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	return c.Client.Create(ctx, obj, append(opts, client.DryRunAll)...)
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	return c.Client.Delete(ctx, obj, append(opts, client.DryRunAll)...)
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return c.Client.Update(ctx, obj, append(opts, client.DryRunAll)...)
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.Client.Patch(ctx, obj, patch, append(opts, client.DryRunAll)...)
+}
+
+func (c *dryRunClient) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+	return c.Client.DeleteAllOf(ctx, obj, append(opts, dryRunDeleteAllOf{})...)
+}
+
+// withoutDryRun returns the client that the dry run client wraps, for writes that only record that the controller has handled
+// an object, and have to be persisted for the controller to make progress.
+func withoutDryRun(c client.Client) client.Client {
+	if d, ok := c.(*dryRunClient); ok {
+		return d.Client
+	}
+
+	return c
+}
+
+// dryRunDeleteAllOf sets the dry run option for delete collection requests.
+type dryRunDeleteAllOf struct{}
+
+func (dryRunDeleteAllOf) ApplyToDeleteAllOf(opts *client.DeleteAllOfOptions) {
+	opts.DryRun = []string{metav1.DryRunAll}
+}
+
+// dryRunRecorder marks all recorded events as being the result of a dry run.
+type dryRunRecorder struct {
+	record.EventRecorder
+}
+
+func (r *dryRunRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(object, eventtype, reason, "[DryRun] "+message)
+}
+
+func (r *dryRunRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Eventf(object, eventtype, reason, "[DryRun] "+messageFmt, args...)
+}
+
+func (r *dryRunRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "[DryRun] "+messageFmt, args...)
+}
+
+// setDryRunCondition marks the status with the actions that would have been taken if not running in dry run mode.
+// The condition is removed when not running in dry run mode.
+func setDryRunCondition(conditions []delav1alpha1.Condition, dryRun bool, actions []string) []delav1alpha1.Condition {
+	if !dryRun {
+		return delav1alpha1.RemoveCondition(conditions, delav1alpha1.ConditionDryRun)
+	}
+
+	message := "No changes"
+	if len(actions) > 0 {
+		message = "Would have " + strings.Join(actions, ", ")
+	}
+	return delav1alpha1.SetCondition(conditions, delav1alpha1.Condition{
+		Type:    delav1alpha1.ConditionDryRun,
+		Status:  corev1.ConditionTrue,
+		Reason:  "DryRun",
+		Message: message,
+	})
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Dry Run Client", func() {
+	ctx := context.TODO()
+	ns := SetupTestNamespace(ctx)
+
+	It("Does not persist writes", func() {
+		dryRun := &dryRunClient{Client: k8sClient}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "main",
+				Namespace: ns.Name,
+			},
+			Data: map[string][]byte{"foo": []byte("bar")},
+		}
+
+		By("Creating a Secret")
+		Expect(dryRun.Create(ctx, secret)).Should(Succeed())
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+
+		By("Updating an existing Secret")
+		secret.ResourceVersion = ""
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		secret.Data["foo"] = []byte("baz")
+		Expect(dryRun.Update(ctx, secret)).Should(Succeed())
+		existing := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, existing)).Should(Succeed())
+		Expect(existing.Data["foo"]).Should(Equal([]byte("bar")))
+
+		By("Deleting an existing Secret")
+		Expect(dryRun.Delete(ctx, existing)).Should(Succeed())
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, &corev1.Secret{})).Should(Succeed())
+	})
+})
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// createImmutableSecret creates the Secret with the immutable field set, so that it is never mutable after creation.
+// The field is set on an unstructured copy as it is not part of the Secret type of the Kubernetes API version in use.
+func createImmutableSecret(ctx context.Context, c client.Client, secret *corev1.Secret) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(secret)
+	if err != nil {
+		return err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	if err := unstructured.SetNestedField(u.Object, true, "immutable"); err != nil {
+		return err
+	}
+	if err := c.Create(ctx, u); err != nil {
+		return err
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, secret)
+}
+
+// replaceSecretCopy creates the Secret copy, replacing the existing Secret copy instead of updating it when it has to change.
+// Immutable Secrets can not be updated, so this is used when either the existing or the new Secret copy is immutable.
+func (r *RequestReconciler) replaceSecretCopy(ctx context.Context, request *delav1alpha1.Request, secretCopy *corev1.Secret, existing *corev1.Secret, mutate controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	if err := mutate(); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	if request.Spec.Immutable {
+		secretCopy.Annotations[delav1alpha1.ImmutableAnnotation] = "true"
+	}
+
+	result := controllerutil.OperationResultCreated
+	if existing != nil {
+		if metav1.IsControlledBy(existing, request) &&
+			existing.Annotations[delav1alpha1.ImmutableAnnotation] == secretCopy.Annotations[delav1alpha1.ImmutableAnnotation] &&
+			existing.Annotations[delav1alpha1.SourceResourceVersionAnnotation] == secretCopy.Annotations[delav1alpha1.SourceResourceVersionAnnotation] &&
+			secretDataEqual(existing.Data, secretCopy.Data) {
+			*secretCopy = *existing
+			return controllerutil.OperationResultNone, nil
+		}
+
+		uid := existing.UID
+		if err := r.Delete(ctx, existing, client.Preconditions{UID: &uid}); client.IgnoreNotFound(err) != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		result = controllerutil.OperationResultUpdated
+		// The existing Secret copy is not deleted in a dry run, so creating the replacement would fail
+		if r.DryRun {
+			return result, nil
+		}
+	}
+
+	var err error
+	if request.Spec.Immutable {
+		err = createImmutableSecret(ctx, r.Client, secretCopy)
+	} else {
+		err = r.Create(ctx, secretCopy)
+	}
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+const (
+	// ImportFormatKubed imports Secrets replicated with the kubed sync annotation.
+	ImportFormatKubed = "kubed"
+	// ImportFormatReflector imports Secrets replicated with the emberstack reflector annotations.
+	ImportFormatReflector = "reflector"
+)
+
+const (
+	kubedSyncAnnotation                  = "kubed.appscode.com/sync"
+	kubedOriginAnnotation                = "kubed.appscode.com/origin"
+	reflectorAllowedAnnotation           = "reflector.v1.k8s.emberstack.com/reflection-allowed"
+	reflectorAllowedNamespacesAnnotation = "reflector.v1.k8s.emberstack.com/reflection-allowed-namespaces"
+	reflectorReflectsAnnotation          = "reflector.v1.k8s.emberstack.com/reflects"
+)
+
+// ImportReconciler creates Intents and Requests equivalent to the replication annotations of kubed or reflector.
+// Source Secrets get an Intent and their copies get a Request that adopts the existing copy, so that
+// replication can be moved to dela without re-creating any Secret. Existing Intents and Requests are never changed.
+type ImportReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Annotation formats to import.
+	Formats []string
+}
+
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=intents,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=requests,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+func (r *ImportReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, req.NamespacedName, secret); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if secret.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	for _, format := range r.Formats {
+		var err error
+		switch format {
+		case ImportFormatKubed:
+			err = r.importKubed(ctx, secret)
+		case ImportFormatReflector:
+			err = r.importReflector(ctx, secret)
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// importKubed imports a Secret synced by kubed, or a copy made by kubed.
+func (r *ImportReconciler) importKubed(ctx context.Context, secret *corev1.Secret) error {
+	if value, ok := secret.Annotations[kubedSyncAnnotation]; ok {
+		// An empty value syncs to all namespaces, otherwise it is a namespace label selector
+		var selector *metav1.LabelSelector
+		if value != "" {
+			var err error
+			selector, err = metav1.ParseToLabelSelector(value)
+			if err != nil {
+				r.Recorder.Eventf(secret, corev1.EventTypeWarning, "InvalidAnnotation", "Could not parse annotation %s: %v", kubedSyncAnnotation, err)
+				return nil
+			}
+		}
+		if err := r.importIntent(ctx, secret, nil, selector, ImportFormatKubed); err != nil {
+			return err
+		}
+	}
+
+	if value, ok := secret.Annotations[kubedOriginAnnotation]; ok {
+		origin := struct {
+			Namespace string `json:"namespace"`
+			Name      string `json:"name"`
+		}{}
+		if err := json.Unmarshal([]byte(value), &origin); err != nil || origin.Namespace == "" || origin.Name == "" {
+			r.Recorder.Eventf(secret, corev1.EventTypeWarning, "InvalidAnnotation", "Could not parse annotation %s", kubedOriginAnnotation)
+			return nil
+		}
+		if err := r.importRequest(ctx, secret, origin.Namespace, origin.Name, ImportFormatKubed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importReflector imports a Secret that allows reflection, or a mirror made by reflector.
+func (r *ImportReconciler) importReflector(ctx context.Context, secret *corev1.Secret) error {
+	if strings.EqualFold(secret.Annotations[reflectorAllowedAnnotation], "true") {
+		// Reflector allows all namespaces if the list is empty, and supports regex like the namespace whitelist
+		whitelist := splitAnnotationList(secret.Annotations[reflectorAllowedNamespacesAnnotation])
+		if err := r.importIntent(ctx, secret, whitelist, nil, ImportFormatReflector); err != nil {
+			return err
+		}
+	}
+
+	if value, ok := secret.Annotations[reflectorReflectsAnnotation]; ok {
+		parts := strings.Split(strings.TrimSpace(value), "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			r.Recorder.Eventf(secret, corev1.EventTypeWarning, "InvalidAnnotation", "Annotation %s has to be in the format namespace/name", reflectorReflectsAnnotation)
+			return nil
+		}
+		if err := r.importRequest(ctx, secret, parts[0], parts[1], ImportFormatReflector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importIntent creates an Intent sharing the Secret unless an Intent with the same name already exists.
+func (r *ImportReconciler) importIntent(ctx context.Context, secret *corev1.Secret, whitelist []string, selector *metav1.LabelSelector, format string) error {
+	intent := &delav1alpha1.Intent{}
+	err := r.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, intent)
+	if client.IgnoreNotFound(err) != nil || err == nil {
+		return err
+	}
+
+	intent = &delav1alpha1.Intent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Annotations: map[string]string{delav1alpha1.ImportedFromAnnotation: format},
+		},
+		Spec: delav1alpha1.IntentSpec{
+			SecretName:         secret.Name,
+			NamespaceWhitelist: whitelist,
+			NamespaceSelector:  selector,
+		},
+	}
+	if err := r.Create(ctx, intent); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(secret, corev1.EventTypeNormal, "Imported", "Created Intent %q from %s annotations", intent.Name, format)
+	return nil
+}
+
+// importRequest creates a Request adopting the Secret copy unless a Request with the same name already exists.
+func (r *ImportReconciler) importRequest(ctx context.Context, secret *corev1.Secret, namespace, name, format string) error {
+	request := &delav1alpha1.Request{}
+	err := r.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, request)
+	if client.IgnoreNotFound(err) != nil || err == nil {
+		return err
+	}
+
+	request = &delav1alpha1.Request{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Annotations: map[string]string{delav1alpha1.ImportedFromAnnotation: format},
+		},
+		Spec: delav1alpha1.RequestSpec{
+			IntentRef:        delav1alpha1.IntentReference{Namespace: namespace, Name: name},
+			SecretObjectMeta: metav1.ObjectMeta{Name: secret.Name},
+			// The copy should already contain the source data, adopting it always avoids downtime if it is stale
+			AdoptionPolicy: delav1alpha1.AdoptionPolicyAlways,
+		},
+	}
+	if err := r.Create(ctx, request); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(secret, corev1.EventTypeNormal, "Imported", "Created Request %q from %s annotations", request.Name, format)
+	return nil
+}
+
+func (r *ImportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	for _, format := range r.Formats {
+		if format != ImportFormatKubed && format != ImportFormatReflector {
+			return fmt.Errorf("unknown import format %q", format)
+		}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("import").
+		For(&corev1.Secret{}).
+		Complete(r)
+}
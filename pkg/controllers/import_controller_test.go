@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+var _ = Describe("Import Controller", func() {
+	const timeout = time.Second * 30
+	const interval = time.Second * 1
+
+	ctx := context.TODO()
+	source := SetupTestNamespace(ctx)
+	dest := SetupTestNamespace(ctx)
+
+	// importsCopy creates a source Secret and a copy with the given annotations, and expects the copy to be adopted.
+	importsCopy := func(sourceAnnotations, copyAnnotations map[string]string, format string) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: source.Name, Annotations: sourceAnnotations},
+			Data:       map[string][]byte{"foo": []byte("bar")},
+		}
+		secretCopy := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: dest.Name, Annotations: copyAnnotations},
+			Data:       map[string][]byte{"foo": []byte("bar")},
+		}
+
+		By("Creating a replicated Secret and its copy")
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, secretCopy)).Should(Succeed())
+		Eventually(func() map[string]string {
+			intent := &delav1alpha1.Intent{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, intent)
+			return intent.Annotations
+		}, timeout, interval).Should(HaveKeyWithValue(delav1alpha1.ImportedFromAnnotation, format))
+		Eventually(func() delav1alpha1.RequestState {
+			request := &delav1alpha1.Request{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: secretCopy.Name, Namespace: secretCopy.Namespace}, request)
+			return request.Status.State
+		}, timeout, interval).Should(Equal(delav1alpha1.RequestStateReady))
+
+		By("Checking that the copy was adopted")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secretCopy.Name, Namespace: secretCopy.Namespace}, secretCopy)).Should(Succeed())
+		Expect(metav1.GetControllerOf(secretCopy)).ShouldNot(BeNil())
+		Expect(metav1.GetControllerOf(secretCopy).Kind).Should(Equal("Request"))
+		Expect(secretCopy.Data["foo"]).Should(Equal([]byte("bar")))
+	}
+
+	It("Imports Secrets replicated by kubed", func() {
+		origin := fmt.Sprintf(`{"group":"","kind":"Secret","namespace":%q,"name":"main"}`, source.Name)
+		importsCopy(
+			map[string]string{"kubed.appscode.com/sync": ""},
+			map[string]string{"kubed.appscode.com/origin": origin},
+			ImportFormatKubed,
+		)
+	})
+
+	It("Imports the kubed namespace selector", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "selector",
+				Namespace:   source.Name,
+				Annotations: map[string]string{"kubed.appscode.com/sync": "import=" + dest.Name},
+			},
+			Data: map[string][]byte{"foo": []byte("bar")},
+		}
+		request := &delav1alpha1.Request{
+			ObjectMeta: metav1.ObjectMeta{Name: "selector", Namespace: dest.Name},
+			Spec: delav1alpha1.RequestSpec{
+				IntentRef: delav1alpha1.IntentReference{Name: secret.Name, Namespace: secret.Namespace},
+			},
+		}
+		getRequestState := func() delav1alpha1.RequestState {
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, request)
+			return request.Status.State
+		}
+
+		By("Creating a Secret with a selector that does not match any namespace")
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		Eventually(func() *metav1.LabelSelector {
+			intent := &delav1alpha1.Intent{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, intent)
+			return intent.Spec.NamespaceSelector
+		}, timeout, interval).Should(Equal(&metav1.LabelSelector{MatchLabels: map[string]string{"import": dest.Name}}))
+		Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+		Eventually(getRequestState, timeout, interval).Should(Equal(delav1alpha1.RequestStateError))
+
+		By("Labeling a namespace")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: dest.Name}, dest)).Should(Succeed())
+		dest.Labels = map[string]string{"import": dest.Name}
+		Expect(k8sClient.Update(ctx, dest)).Should(Succeed())
+		Eventually(getRequestState, timeout, interval).Should(Equal(delav1alpha1.RequestStateReady))
+	})
+
+	It("Imports Secrets replicated by reflector", func() {
+		importsCopy(
+			map[string]string{
+				"reflector.v1.k8s.emberstack.com/reflection-allowed":            "true",
+				"reflector.v1.k8s.emberstack.com/reflection-allowed-namespaces": dest.Name,
+			},
+			map[string]string{"reflector.v1.k8s.emberstack.com/reflects": source.Name + "/main"},
+			ImportFormatReflector,
+		)
+	})
+})
@@ -2,9 +2,13 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -12,9 +16,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
 )
 
 // IntentReconciler reconciles a Intent object
@@ -23,11 +29,23 @@ type IntentReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// Performs all Secret writes as dry runs.
+	DryRun bool
+	// Receives audit records of all decisions, disabled if nil.
+	Audit audit.Sink
+	// Reads the shared data, defaults to reading Secrets.
+	Sources SecretSources
+	// Reads Pods that consume Secret copies without caching them, defaults to the API reader of the manager.
+	APIReader client.Reader
 }
 
 // +kubebuilder:rbac:groups=dela.phillebaba.io,resources=intents,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dela.phillebaba.io,resources=intents/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=sharepolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=requests,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 func (r *IntentReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
@@ -35,33 +53,176 @@ func (r *IntentReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	intent := &delav1alpha1.Intent{}
 	if err := r.Get(ctx, req.NamespacedName, intent); err != nil {
+		if apierrors.IsNotFound(err) {
+			deleteCertificateMetrics(req.Namespace, req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Block deletion while Ready Requests reference a protected Intent
+	deleting, err := r.reconcileProtection(ctx, intent)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if deleting {
+		return ctrl.Result{}, nil
+	}
+
+	previousState := intent.Status.State
+	original := intent.DeepCopy()
+	dryRunActions := []string{}
 	defer func() {
-		if err := r.Status().Update(ctx, intent); err != nil {
+		intent.Status.Conditions = setDryRunCondition(intent.Status.Conditions, r.DryRun, dryRunActions)
+		intent.Status.ObservedGeneration = intent.Generation
+		if equality.Semantic.DeepEqual(original.Status, intent.Status) {
+			return
+		}
+		if err := r.Status().Patch(ctx, intent, client.MergeFrom(original)); err != nil {
 			log.Error(err, "Could not update status")
 		}
 	}()
 
-	secret := &corev1.Secret{}
-	if err := r.Get(ctx, types.NamespacedName{Name: intent.Spec.SecretName, Namespace: intent.Namespace}, secret); err != nil {
+	violation, err := IntentPolicyViolation(ctx, r, intent)
+	if err != nil {
 		intent.Status.State = delav1alpha1.IntentStateError
-		r.Recorder.Event(intent, corev1.EventTypeNormal, "MissingSecret", "Can't get Secret specified by Intent")
 		return ctrl.Result{}, err
 	}
+	intent.Status.Conditions = setPolicyViolationCondition(intent.Status.Conditions, violation)
+	if violation != "" {
+		intent.Status.State = delav1alpha1.IntentStatePolicyViolation
+		r.Recorder.Event(intent, corev1.EventTypeNormal, "PolicyViolation", violation)
+		r.auditIntentDenied(intent, previousState, "PolicyViolation", nil)
+		return ctrl.Result{}, nil
+	}
 
-	if err := r.setOwnerReference(intent, secret); err != nil {
+	secretSource, err := r.Sources.For(intent)
+	if err != nil {
+		intent.Status.State = delav1alpha1.IntentStateError
+		r.Recorder.Event(intent, corev1.EventTypeNormal, "InvalidSource", err.Error())
+		return ctrl.Result{}, nil
+	}
+	secret, err := secretSource.GetSecret(ctx, intent)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// A new Secret has no owner reference to the Intent yet, so it is not picked up by the watch
+			intent.Status.State = delav1alpha1.IntentStatePending
+			if intent.Spec.Source != nil {
+				r.Recorder.Event(intent, corev1.EventTypeNormal, "MissingSource", "Can't get data from source specified by Intent")
+			} else {
+				r.Recorder.Event(intent, corev1.EventTypeNormal, "MissingSecret", "Can't get Secret specified by Intent")
+			}
+			return ctrl.Result{RequeueAfter: pendingRequeueAfter}, nil
+		}
 		intent.Status.State = delav1alpha1.IntentStateError
-		r.Recorder.Event(intent, corev1.EventTypeNormal, "OwnerReference", "Could not set owner reference on Secret")
 		return ctrl.Result{}, err
 	}
 
+	// Data from outside the cluster can not be watched, so it is read again periodically
+	result := ctrl.Result{}
+	if intent.Spec.Source != nil {
+		result.RequeueAfter = refreshInterval(intent)
+	} else {
+		updated, err := r.setOwnerReference(intent, secret)
+		if err != nil {
+			intent.Status.State = delav1alpha1.IntentStateError
+			r.Recorder.Event(intent, corev1.EventTypeNormal, "OwnerReference", "Could not set owner reference on Secret")
+			return ctrl.Result{}, err
+		}
+		if updated {
+			dryRunActions = append(dryRunActions, fmt.Sprintf("set owner reference on Secret %q", secret.Name))
+		}
+	}
+
+	// Make sure the shared data fulfills the content contract
+	reason, message := contractViolation(intent, secret.Data)
+	intent.Status.Conditions = setContractViolationCondition(intent.Status.Conditions, reason, message)
+	if reason != "" {
+		intent.Status.State = delav1alpha1.IntentStateContractViolation
+		r.Recorder.Event(intent, corev1.EventTypeWarning, "ContractViolation", message)
+		r.auditIntentDenied(intent, previousState, "ContractViolation", secret)
+		return result, nil
+	}
+
+	// Warn about shared certificates that are about to expire
+	expired, requeueAfter := r.reconcileCertificates(intent, secret)
+	if requeueAfter > 0 && (result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = requeueAfter
+	}
+	if expired && intent.Spec.CertificateExpiry != nil && intent.Spec.CertificateExpiry.NotReadyWhenExpired {
+		intent.Status.State = delav1alpha1.IntentStateCertificateExpired
+		r.auditIntentDenied(intent, previousState, "CertificateExpired", secret)
+		return result, nil
+	}
+
+	intent.Status.SecretType = secret.Type
+	intent.Status.Keys = secretKeys(secret)
+	intent.Status.SourceVersion = secret.ResourceVersion
 	intent.Status.State = delav1alpha1.IntentStateReady
-	return ctrl.Result{}, nil
+	r.auditIntentGranted(intent, previousState, secret)
+
+	// Roll out changes to the shared Secret wave by wave
+	if intent.Spec.Rollout == nil {
+		intent.Status.Rollout = nil
+		return result, nil
+	}
+	requeueAfter, err = r.reconcileRollout(ctx, intent, secret)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if requeueAfter > 0 && (result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = requeueAfter
+	}
+	return result, nil
 }
 
 func (r *IntentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+	r.Client = &secretFallbackClient{Client: r.Client, APIReader: mgr.GetAPIReader()}
+	if r.DryRun {
+		r.Client = &dryRunClient{Client: r.Client}
+		r.Recorder = &dryRunRecorder{EventRecorder: r.Recorder}
+	}
+	if r.Sources.Secret == nil {
+		r.Sources.Secret = &KubernetesSecretSource{Client: r.Client}
+	}
+
+	policyMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			ctx := context.Background()
+
+			var intents delav1alpha1.IntentList
+			if err := r.List(ctx, &intents); err != nil {
+				return []reconcile.Request{}
+			}
+			return intentReconcileRequests(intents.Items)
+		},
+	)
+
+	namespaceMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			ctx := context.Background()
+
+			var intents delav1alpha1.IntentList
+			if err := r.List(ctx, &intents, client.InNamespace(a.Meta.GetName())); err != nil {
+				return []reconcile.Request{}
+			}
+			return intentReconcileRequests(intents.Items)
+		},
+	)
+
+	// Rollouts progress as the Requests of a wave are updated
+	requestMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			request := a.Object.(*delav1alpha1.Request)
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{
+				Name:      request.Spec.IntentRef.Name,
+				Namespace: request.Spec.IntentRef.Namespace,
+			}}}
+		},
+	)
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&delav1alpha1.Intent{}).
 		Watches(
@@ -71,17 +232,63 @@ func (r *IntentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				IsController: false,
 			},
 		).
+		Watches(
+			&source.Kind{Type: &delav1alpha1.SharePolicy{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: policyMapFn},
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: namespaceMapFn},
+		).
+		Watches(
+			&source.Kind{Type: &delav1alpha1.Request{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: requestMapFn},
+		).
 		Complete(r)
 }
 
-func (r *IntentReconciler) setOwnerReference(intent *delav1alpha1.Intent, secret *corev1.Secret) error {
+// secretKeys returns the sorted names of the keys in the Secret.
+func secretKeys(secret *corev1.Secret) []string {
+	keys := []string{}
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// intentReconcileRequests creates reconcile requests for the Intents.
+func intentReconcileRequests(intents []delav1alpha1.Intent) []reconcile.Request {
+	reconcileReq := []reconcile.Request{}
+	for _, intent := range intents {
+		reconcileReq = append(reconcileReq, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      intent.Name,
+			Namespace: intent.Namespace,
+		}})
+	}
+
+	return reconcileReq
+}
+
+// setOwnerReference sets an owner reference to the Intent and the managed label on the shared Secret.
+// The Secret is only updated if either is missing, returns if it was updated.
+func (r *IntentReconciler) setOwnerReference(intent *delav1alpha1.Intent, secret *corev1.Secret) (bool, error) {
 	ctx := context.Background()
+	original := secret.ObjectMeta.DeepCopy()
 	if err := controllerutil.SetOwnerReference(intent, secret, r.Scheme); err != nil {
-		return err
+		return false, err
+	}
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[delav1alpha1.ManagedLabel] = "true"
+	if equality.Semantic.DeepEqual(original, &secret.ObjectMeta) {
+		return false, nil
 	}
 	if err := r.Update(ctx, secret); err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
@@ -2,13 +2,24 @@ package controllers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
 )
@@ -60,7 +71,7 @@ var _ = Describe("Intent Controller", func() {
 				_ = k8sClient.Get(ctx, types.NamespacedName{Name: key.Name, Namespace: key.Namespace}, intent)
 				return intent
 			}, timeout, interval).Should(SatisfyAll(
-				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStateError)),
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStatePending)),
 			))
 
 			By("Re-creating the Secret")
@@ -71,8 +82,379 @@ var _ = Describe("Intent Controller", func() {
 				_ = k8sClient.Get(ctx, types.NamespacedName{Name: key.Name, Namespace: key.Namespace}, intent)
 				return intent
 			}, timeout, interval).Should(SatisfyAll(
-				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStateError)),
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStatePending)),
+			))
+		})
+
+		It("Does not write when nothing has changed", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unchanged",
+					Namespace: ns.Name,
+				},
+			}
+			intent := &delav1alpha1.Intent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unchanged",
+					Namespace: ns.Name,
+				},
+				Spec: delav1alpha1.IntentSpec{
+					SecretName: secret.Name,
+				},
+			}
+			key := types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}
+
+			By("Creating a Secret and Intent")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Intent {
+				intent = &delav1alpha1.Intent{}
+				_ = k8sClient.Get(ctx, key, intent)
+				return intent
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStateReady)),
+				WithTransform(func(e *delav1alpha1.Intent) bool { return e.Status.ObservedGeneration == e.Generation }, BeTrue()),
+			))
+
+			By("Reconciling the unchanged Intent")
+			counting := &countingClient{Client: k8sClient}
+			reconciler := &IntentReconciler{
+				Client:   counting,
+				Log:      ctrl.Log.WithName("controllers").WithName("Intent"),
+				Scheme:   scheme.Scheme,
+				Recorder: counting.Recorder(record.NewFakeRecorder(100)),
+				Sources:  SecretSources{Secret: &KubernetesSecretSource{Client: counting}},
+			}
+			Eventually(func() int32 {
+				counting.Reset()
+				_, err := reconciler.Reconcile(ctrl.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				return counting.Writes()
+			}, timeout, interval).Should(BeZero())
+
+			By("Changing the Intent spec")
+			intent.Spec.Description = "changed"
+			Expect(k8sClient.Update(ctx, intent)).Should(Succeed())
+			Eventually(func() bool {
+				intent = &delav1alpha1.Intent{}
+				_ = k8sClient.Get(ctx, key, intent)
+				return intent.Status.ObservedGeneration == intent.Generation
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("Cluster with SharePolicy", func() {
+		It("Does not allow Intents in denied namespaces", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: ns.Name,
+				},
+			}
+			intent := &delav1alpha1.Intent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: ns.Name,
+				},
+				Spec: delav1alpha1.IntentSpec{
+					SecretName: secret.Name,
+				},
+			}
+			policy := &delav1alpha1.SharePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: ns.Name,
+				},
+				Spec: delav1alpha1.SharePolicySpec{
+					Deny: []delav1alpha1.SharePolicyRule{{SourceNamespaces: []string{ns.Name}}},
+				},
+			}
+
+			By("Creating a SharePolicy, Secret, and Intent")
+			Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Intent {
+				intent = &delav1alpha1.Intent{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "main", Namespace: ns.Name}, intent)
+				return intent
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStatePolicyViolation)),
+				WithTransform(func(e *delav1alpha1.Intent) *delav1alpha1.Condition {
+					return delav1alpha1.GetCondition(e.Status.Conditions, delav1alpha1.ConditionPolicyViolation)
+				}, Not(BeNil())),
+			))
+
+			By("Deleting the SharePolicy")
+			Expect(k8sClient.Delete(ctx, policy)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Intent {
+				intent = &delav1alpha1.Intent{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "main", Namespace: ns.Name}, intent)
+				return intent
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStateReady)),
 			))
 		})
 	})
+
+	Context("Intent with deletion protection", func() {
+		It("Blocks deletion while Ready Requests reference the Intent", func() {
+			secret, intent, request := baseResources(ns, ns)
+			intent.Spec.DeletionProtection = true
+			key := types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}
+			getIntent := func() (*delav1alpha1.Intent, error) {
+				intent := &delav1alpha1.Intent{}
+				err := k8sClient.Get(ctx, key, intent)
+				return intent, err
+			}
+
+			By("Creating a Secret, Intent and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() []string {
+				intent, _ := getIntent()
+				return intent.Finalizers
+			}, timeout, interval).Should(ContainElement(delav1alpha1.IntentProtectionFinalizer))
+			Eventually(func() delav1alpha1.RequestState {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r.Status.State
+			}, timeout, interval).Should(Equal(delav1alpha1.RequestStateReady))
+
+			By("Deleting the Intent")
+			intent, err := getIntent()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Delete(ctx, intent)).Should(Succeed())
+			Consistently(func() error {
+				_, err := getIntent()
+				return err
+			}, time.Second*5, interval).Should(Succeed())
+
+			By("Deleting the Request")
+			Expect(k8sClient.Delete(ctx, request)).Should(Succeed())
+			Eventually(func() bool {
+				_, err := getIntent()
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		})
+
+		It("Deletes the Intent when forced", func() {
+			secret, intent, request := baseResources(ns, ns)
+			intent.Spec.DeletionProtection = true
+			key := types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}
+
+			By("Creating a Secret, Intent and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() delav1alpha1.RequestState {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r.Status.State
+			}, timeout, interval).Should(Equal(delav1alpha1.RequestStateReady))
+
+			By("Deleting the Intent with the force annotation")
+			intent = &delav1alpha1.Intent{}
+			Expect(k8sClient.Get(ctx, key, intent)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, intent)).Should(Succeed())
+			Eventually(func() error {
+				intent = &delav1alpha1.Intent{}
+				if err := k8sClient.Get(ctx, key, intent); err != nil {
+					return err
+				}
+				intent.Annotations = map[string]string{delav1alpha1.ForceDeleteAnnotation: "true"}
+				return k8sClient.Update(ctx, intent)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, &delav1alpha1.Intent{})
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		})
+
+		It("Does not add the finalizer in dry run", func() {
+			secret, intent, _ := baseResources(ns, ns)
+			key := types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}
+			r := &IntentReconciler{Client: &dryRunClient{Client: k8sClient}, DryRun: true}
+
+			By("Creating a Secret and Intent")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+
+			By("Reconciling deletion protection in dry run")
+			Expect(k8sClient.Get(ctx, key, intent)).Should(Succeed())
+			intent.Spec.DeletionProtection = true
+			deleting, err := r.reconcileProtection(ctx, intent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleting).To(BeFalse())
+			Expect(k8sClient.Get(ctx, key, intent)).Should(Succeed())
+			Expect(intent.Finalizers).ShouldNot(ContainElement(delav1alpha1.IntentProtectionFinalizer))
+		})
+	})
+
+	Context("Intent with content contract", func() {
+		It("Only shares data that fulfills the contract", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "contract",
+					Namespace: ns.Name,
+				},
+				Data: map[string][]byte{"config": []byte("{")},
+			}
+			intent := &delav1alpha1.Intent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "contract",
+					Namespace: ns.Name,
+				},
+				Spec: delav1alpha1.IntentSpec{
+					SecretName: secret.Name,
+					Contract: &delav1alpha1.ContentContract{
+						Required: []delav1alpha1.KeyContract{{Name: "url", Format: delav1alpha1.KeyFormatURL}},
+						Optional: []delav1alpha1.KeyContract{{Name: "config", Format: delav1alpha1.KeyFormatJSON}},
+					},
+				},
+			}
+			getIntent := func() *delav1alpha1.Intent {
+				intent = &delav1alpha1.Intent{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "contract", Namespace: ns.Name}, intent)
+				return intent
+			}
+
+			By("Creating a Secret missing a required key")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Eventually(getIntent, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStateContractViolation)),
+				WithTransform(func(e *delav1alpha1.Intent) string {
+					condition := delav1alpha1.GetCondition(e.Status.Conditions, delav1alpha1.ConditionContractViolation)
+					if condition == nil {
+						return ""
+					}
+					return condition.Reason
+				}, Equal("MissingKey")),
+			))
+
+			By("Fixing the Secret")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "contract", Namespace: ns.Name}, secret)).Should(Succeed())
+			secret.Data = map[string][]byte{"url": []byte("https://example.com"), "config": []byte("{}")}
+			Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+			Eventually(getIntent, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStateReady)),
+				WithTransform(func(e *delav1alpha1.Intent) *delav1alpha1.Condition {
+					return delav1alpha1.GetCondition(e.Status.Conditions, delav1alpha1.ConditionContractViolation)
+				}, BeNil()),
+			))
+		})
+
+		It("Validates key formats", func() {
+			certPEM, keyPEM := testCertificate(time.Now().Add(time.Hour))
+			Expect(validateKeyFormat(delav1alpha1.KeyFormatPEMCertificate, certPEM)).Should(Succeed())
+			Expect(validateKeyFormat(delav1alpha1.KeyFormatPEMCertificate, keyPEM)).ShouldNot(Succeed())
+			Expect(validateKeyFormat(delav1alpha1.KeyFormatPEMPrivateKey, keyPEM)).Should(Succeed())
+			Expect(validateKeyFormat(delav1alpha1.KeyFormatPEMPrivateKey, certPEM)).ShouldNot(Succeed())
+			Expect(validateKeyFormat(delav1alpha1.KeyFormatNonEmpty, []byte{})).ShouldNot(Succeed())
+			Expect(validateKeyFormat(delav1alpha1.KeyFormatURL, []byte("example.com"))).ShouldNot(Succeed())
+		})
+	})
+
+	Context("Intent sharing a certificate", func() {
+		It("Warns about expiring certificates", func() {
+			certPEM, keyPEM := testCertificate(time.Now().Add(time.Hour), "example.com")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls",
+					Namespace: ns.Name,
+				},
+				Type: corev1.SecretTypeTLS,
+				Data: map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+			}
+			intent := &delav1alpha1.Intent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls",
+					Namespace: ns.Name,
+				},
+				Spec: delav1alpha1.IntentSpec{
+					SecretName:        secret.Name,
+					CertificateExpiry: &delav1alpha1.CertificateExpiryPolicy{NotReadyWhenExpired: true},
+				},
+			}
+			getIntent := func() *delav1alpha1.Intent {
+				intent = &delav1alpha1.Intent{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: "tls", Namespace: ns.Name}, intent)
+				return intent
+			}
+			conditionReason := func(e *delav1alpha1.Intent) string {
+				condition := delav1alpha1.GetCondition(e.Status.Conditions, delav1alpha1.ConditionCertificateExpiring)
+				if condition == nil {
+					return ""
+				}
+				return condition.Reason
+			}
+
+			By("Creating a Secret with a certificate expiring within the warning window")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Eventually(getIntent, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStateReady)),
+				WithTransform(conditionReason, Equal("Expiring")),
+				WithTransform(func(e *delav1alpha1.Intent) []string {
+					if len(e.Status.Certificates) != 1 {
+						return nil
+					}
+					return e.Status.Certificates[0].SubjectAltNames
+				}, Equal([]string{"example.com"})),
+			))
+
+			By("Replacing the certificate with an expired one")
+			certPEM, keyPEM = testCertificate(time.Now().Add(-time.Hour), "example.com")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "tls", Namespace: ns.Name}, secret)).Should(Succeed())
+			secret.Data = map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM}
+			Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+			Eventually(getIntent, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Intent) delav1alpha1.IntentState { return e.Status.State }, Equal(delav1alpha1.IntentStateCertificateExpired)),
+				WithTransform(conditionReason, Equal("Expired")),
+			))
+		})
+
+		It("Only warns when the expiry changes", func() {
+			recorder := record.NewFakeRecorder(10)
+			r := &IntentReconciler{Recorder: recorder}
+			intent := &delav1alpha1.Intent{ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default"}}
+			certPEM, _ := testCertificate(time.Now().Add(time.Hour), "example.com")
+			secret := &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: certPEM}}
+
+			By("Reconciling a certificate within the warning window twice")
+			expired, requeueAfter := r.reconcileCertificates(intent, secret)
+			Expect(expired).To(BeFalse())
+			Expect(requeueAfter).To(BeNumerically(">", time.Hour-time.Minute))
+			_, _ = r.reconcileCertificates(intent, secret)
+			Expect(recorder.Events).To(HaveLen(1))
+
+			By("Replacing the certificate with an expired one")
+			certPEM, _ = testCertificate(time.Now().Add(-time.Hour), "example.com")
+			secret.Data[corev1.TLSCertKey] = certPEM
+			expired, _ = r.reconcileCertificates(intent, secret)
+			Expect(expired).To(BeTrue())
+			_, _ = r.reconcileCertificates(intent, secret)
+			Expect(recorder.Events).To(HaveLen(2))
+		})
+	})
 })
+
+// testCertificate creates a self-signed PEM encoded certificate and private key that expire at the given time.
+func testCertificate(notAfter time.Time, dnsNames ...string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dela"},
+		DNSNames:     dnsNames,
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
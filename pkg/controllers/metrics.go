@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+var (
+	requestDriftTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dela_request_drift_total",
+			Help: "Number of times drift has been detected in a Secret copy.",
+		},
+		[]string{"namespace", "request"},
+	)
+	intentCertificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "dela_intent_certificate_expiry_timestamp_seconds",
+			Help: "Time when a certificate shared by an Intent expires.",
+		},
+		[]string{"namespace", "intent", "key"},
+	)
+	intentCertificateExpiring = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "dela_intent_certificate_expiring",
+			Help: "Whether a certificate shared by an Intent expires within the warning window or has expired.",
+		},
+		[]string{"namespace", "intent", "key"},
+	)
+)
+
+// certificateMetricKeys tracks the keys with certificate metrics of each Intent, so that stale series can be removed.
+var certificateMetricKeys = struct {
+	sync.Mutex
+	keys map[string][]string
+}{keys: map[string][]string{}}
+
+func init() {
+	metrics.Registry.MustRegister(requestDriftTotal, intentCertificateExpiry, intentCertificateExpiring)
+}
+
+// setCertificateMetrics replaces the certificate metrics of the Intent.
+func setCertificateMetrics(namespace, name string, certs []delav1alpha1.CertificateInfo, window time.Duration) {
+	deleteCertificateMetrics(namespace, name)
+
+	certificateMetricKeys.Lock()
+	defer certificateMetricKeys.Unlock()
+	keys := []string{}
+	for _, cert := range certs {
+		expiring := 0.0
+		if time.Until(cert.NotAfter.Time) <= window {
+			expiring = 1
+		}
+		intentCertificateExpiry.WithLabelValues(namespace, name, cert.Key).Set(float64(cert.NotAfter.Unix()))
+		intentCertificateExpiring.WithLabelValues(namespace, name, cert.Key).Set(expiring)
+		keys = append(keys, cert.Key)
+	}
+	if len(keys) > 0 {
+		certificateMetricKeys.keys[namespace+"/"+name] = keys
+	}
+}
+
+// deleteCertificateMetrics removes all certificate metrics of the Intent.
+func deleteCertificateMetrics(namespace, name string) {
+	certificateMetricKeys.Lock()
+	defer certificateMetricKeys.Unlock()
+	for _, key := range certificateMetricKeys.keys[namespace+"/"+name] {
+		intentCertificateExpiry.DeleteLabelValues(namespace, name, key)
+		intentCertificateExpiring.DeleteLabelValues(namespace, name, key)
+	}
+	delete(certificateMetricKeys.keys, namespace+"/"+name)
+}
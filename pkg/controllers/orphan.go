@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
+)
+
+// provenanceAnnotations are removed from orphaned Secret copies, as they describe the relation to the Request.
+var provenanceAnnotations = []string{
+	delav1alpha1.SourceResourceVersionAnnotation,
+	delav1alpha1.DataVersionAnnotation,
+	delav1alpha1.ImmutableAnnotation,
+}
+
+// provenanceLabels are removed from orphaned Secret copies, as they describe the relation to the Request.
+var provenanceLabels = []string{
+	delav1alpha1.ManagedLabel,
+	delav1alpha1.RequestLabel,
+	delav1alpha1.VersionLabel,
+}
+
+// reconcileDeletionPolicy adds or removes the orphan finalizer of the Request, and orphans the Secret copies of a deleted Request
+// before removing the finalizer. Returns if the Request is being deleted.
+// The finalizer is never added in dry run, but is always removed, as a finalizer that is not removed blocks deletion.
+func (r *RequestReconciler) reconcileDeletionPolicy(ctx context.Context, request *delav1alpha1.Request) (bool, error) {
+	orphan := containsFinalizer(request.Finalizers, delav1alpha1.RequestOrphanFinalizer)
+	patch := client.MergeFrom(request.DeepCopy())
+	if request.DeletionTimestamp == nil {
+		switch {
+		case request.Spec.DeletionPolicy == delav1alpha1.DeletionPolicyOrphan && !orphan && !r.DryRun:
+			controllerutil.AddFinalizer(request, delav1alpha1.RequestOrphanFinalizer)
+		case request.Spec.DeletionPolicy != delav1alpha1.DeletionPolicyOrphan && orphan:
+			controllerutil.RemoveFinalizer(request, delav1alpha1.RequestOrphanFinalizer)
+		default:
+			return false, nil
+		}
+		return false, withoutDryRun(r.Client).Patch(ctx, request, patch)
+	}
+	if !orphan {
+		return true, nil
+	}
+
+	if err := r.orphanSecretCopies(ctx, request); err != nil {
+		return true, err
+	}
+	controllerutil.RemoveFinalizer(request, delav1alpha1.RequestOrphanFinalizer)
+
+	return true, withoutDryRun(r.Client).Patch(ctx, request, patch)
+}
+
+// orphanSecretCopies removes the owner reference to the Request and the provenance metadata from all Secret copies of the Request,
+// so that they are not garbage collected with the Request.
+// The Secret copies are orphaned even in dry run, as they would otherwise be deleted once the finalizer is removed.
+func (r *RequestReconciler) orphanSecretCopies(ctx context.Context, request *delav1alpha1.Request) error {
+	var childSecrets corev1.SecretList
+	if err := r.List(ctx, &childSecrets, client.InNamespace(request.Namespace), client.MatchingFields{jobOwnerKey: request.Name}); err != nil {
+		return err
+	}
+	copies := map[string]*corev1.Secret{}
+	for i := range childSecrets.Items {
+		copies[childSecrets.Items[i].Name] = &childSecrets.Items[i]
+	}
+	// The Secret copy may not be cached if it has not been labeled yet
+	if _, ok := copies[request.Spec.SecretObjectMeta.Name]; !ok {
+		secretCopy := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if err == nil {
+			copies[secretCopy.Name] = secretCopy
+		}
+	}
+
+	for _, secretCopy := range copies {
+		if !metav1.IsControlledBy(secretCopy, request) {
+			continue
+		}
+		ownerReferences := []metav1.OwnerReference{}
+		for _, ref := range secretCopy.OwnerReferences {
+			if ref.UID != request.UID {
+				ownerReferences = append(ownerReferences, ref)
+			}
+		}
+		secretCopy.OwnerReferences = ownerReferences
+		for _, annotation := range provenanceAnnotations {
+			delete(secretCopy.Annotations, annotation)
+		}
+		for _, label := range provenanceLabels {
+			delete(secretCopy.Labels, label)
+		}
+		if err := withoutDryRun(r.Client).Update(ctx, secretCopy); err != nil {
+			return err
+		}
+		r.Recorder.Event(request, corev1.EventTypeNormal, "Orphaned", fmt.Sprintf("Orphaned Secret %q", secretCopy.Name))
+		r.auditRequest(request, audit.ActionOrphaned, "DeletionPolicy", secretCopy.Name, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// IntentPolicyViolation checks if the Intent is allowed by all SharePolicies.
+// Returns a message describing the violation, or an empty string if the Intent is allowed.
+func IntentPolicyViolation(ctx context.Context, c client.Client, intent *delav1alpha1.Intent) (string, error) {
+	var policies delav1alpha1.SharePolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return "", err
+	}
+
+	var namespace *corev1.Namespace
+	for _, policy := range policies.Items {
+		if policy.Spec.IntentNamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(policy.Spec.IntentNamespaceSelector)
+			if err != nil {
+				return "", err
+			}
+			if namespace == nil {
+				namespace = &corev1.Namespace{}
+				if err := c.Get(ctx, types.NamespacedName{Name: intent.Namespace}, namespace); err != nil {
+					return "", err
+				}
+			}
+			if !selector.Matches(labels.Set(namespace.Labels)) {
+				return fmt.Sprintf("SharePolicy %s does not allow Intents in namespace %s", policy.Name, intent.Namespace), nil
+			}
+		}
+
+		for _, rule := range policy.Spec.Deny {
+			if len(rule.DestinationNamespaces) != 0 {
+				continue
+			}
+			matches, err := matchesNamespaceWhitelist(intent.Namespace, rule.SourceNamespaces)
+			if err != nil {
+				return "", err
+			}
+			if matches {
+				return fmt.Sprintf("SharePolicy %s denies sharing from namespace %s", policy.Name, intent.Namespace), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// RequestPolicyViolation checks if sharing from the Intent namespace to the Request namespace is allowed by all SharePolicies.
+// Returns a message describing the violation, or an empty string if the Request is allowed.
+func RequestPolicyViolation(ctx context.Context, c client.Client, request *delav1alpha1.Request) (string, error) {
+	var policies delav1alpha1.SharePolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return "", err
+	}
+
+	source := request.Spec.IntentRef.Namespace
+	for _, policy := range policies.Items {
+		for _, rule := range policy.Spec.Deny {
+			sourceMatches, err := matchesNamespaceWhitelist(source, rule.SourceNamespaces)
+			if err != nil {
+				return "", err
+			}
+			destMatches, err := matchesNamespaceWhitelist(request.Namespace, rule.DestinationNamespaces)
+			if err != nil {
+				return "", err
+			}
+			if sourceMatches && destMatches {
+				return fmt.Sprintf("SharePolicy %s denies sharing from namespace %s to namespace %s", policy.Name, source, request.Namespace), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// setPolicyViolationCondition sets the policy violation condition with the violation message.
+// The condition is removed if there is no violation.
+func setPolicyViolationCondition(conditions []delav1alpha1.Condition, violation string) []delav1alpha1.Condition {
+	if violation == "" {
+		return delav1alpha1.RemoveCondition(conditions, delav1alpha1.ConditionPolicyViolation)
+	}
+
+	return delav1alpha1.SetCondition(conditions, delav1alpha1.Condition{
+		Type:    delav1alpha1.ConditionPolicyViolation,
+		Status:  corev1.ConditionTrue,
+		Reason:  "SharePolicy",
+		Message: violation,
+	})
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// containsFinalizer checks if the finalizer is set in the list of finalizers.
+func containsFinalizer(finalizers []string, finalizer string) bool {
+	for _, f := range finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// blockingRequests returns the namespaced names of the Ready Requests that reference the Intent, ordered by name.
+func blockingRequests(ctx context.Context, c client.Client, intent *delav1alpha1.Intent) ([]string, error) {
+	var requestList delav1alpha1.RequestList
+	nn := types.NamespacedName{Namespace: intent.Namespace, Name: intent.Name}
+	if err := c.List(ctx, &requestList, client.MatchingFields{intentRefKey: nn.String()}); err != nil {
+		return nil, err
+	}
+	blocking := []string{}
+	for _, request := range requestList.Items {
+		if request.DeletionTimestamp == nil && request.Status.State == delav1alpha1.RequestStateReady {
+			blocking = append(blocking, requestKey(&request))
+		}
+	}
+	sort.Strings(blocking)
+
+	return blocking, nil
+}
+
+// reconcileProtection adds or removes the protection finalizer of the Intent, and removes it from a deleted Intent
+// once no Ready Requests reference it or deletion is forced. Returns if the Intent is being deleted.
+// The finalizer is never added in dry run, but is always removed, as a finalizer that is not removed blocks deletion.
+func (r *IntentReconciler) reconcileProtection(ctx context.Context, intent *delav1alpha1.Intent) (bool, error) {
+	protected := containsFinalizer(intent.Finalizers, delav1alpha1.IntentProtectionFinalizer)
+	patch := client.MergeFrom(intent.DeepCopy())
+	if intent.DeletionTimestamp == nil {
+		switch {
+		case intent.Spec.DeletionProtection && !protected && !r.DryRun:
+			controllerutil.AddFinalizer(intent, delav1alpha1.IntentProtectionFinalizer)
+		case !intent.Spec.DeletionProtection && protected:
+			controllerutil.RemoveFinalizer(intent, delav1alpha1.IntentProtectionFinalizer)
+		default:
+			return false, nil
+		}
+		return false, withoutDryRun(r.Client).Patch(ctx, intent, patch)
+	}
+	if !protected {
+		return true, nil
+	}
+
+	if _, ok := intent.Annotations[delav1alpha1.ForceDeleteAnnotation]; !ok {
+		blocking, err := blockingRequests(ctx, r, intent)
+		if err != nil {
+			return true, err
+		}
+		if len(blocking) > 0 {
+			r.Recorder.Event(intent, corev1.EventTypeWarning, "DeletionBlocked", fmt.Sprintf("Deletion is blocked by Ready Requests: %s", strings.Join(blocking, ", ")))
+			return true, nil
+		}
+	}
+	controllerutil.RemoveFinalizer(intent, delav1alpha1.IntentProtectionFinalizer)
+
+	return true, withoutDryRun(r.Client).Patch(ctx, intent, patch)
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// QuotaExceeded checks if the Request exceeds the max requests of the Intent or any RequestQuota.
+// Only granted Requests count towards the quotas, so denied and pending Requests do not take up a slot.
+// A granted Request keeps its slot unless Requests granted before it were created earlier and fill the quota,
+// while any other Request has to fit in the quota next to all granted Requests.
+// Returns a message describing the exceeded quota, or an empty string if no quota is exceeded.
+func QuotaExceeded(ctx context.Context, c client.Client, request *delav1alpha1.Request, intent *delav1alpha1.Intent) (string, error) {
+	if intent != nil && intent.Spec.MaxRequests != nil {
+		var requests delav1alpha1.RequestList
+		nn := types.NamespacedName{Namespace: intent.Namespace, Name: intent.Name}
+		if err := c.List(ctx, &requests, client.MatchingFields{intentRefKey: nn.String()}); err != nil {
+			return "", err
+		}
+		if grantedRequests(request, requests.Items) >= int(*intent.Spec.MaxRequests) {
+			return fmt.Sprintf("Intent %s allows at most %d Requests", nn, *intent.Spec.MaxRequests), nil
+		}
+	}
+
+	var quotas delav1alpha1.RequestQuotaList
+	if err := c.List(ctx, &quotas); err != nil {
+		return "", err
+	}
+	var requests *delav1alpha1.RequestList
+	for _, quota := range quotas.Items {
+		matches, err := matchesNamespaceWhitelist(request.Namespace, quota.Spec.Namespaces)
+		if err != nil {
+			return "", err
+		}
+		if !matches {
+			continue
+		}
+
+		if requests == nil {
+			requests = &delav1alpha1.RequestList{}
+			if err := c.List(ctx, requests, client.InNamespace(request.Namespace)); err != nil {
+				return "", err
+			}
+		}
+		if grantedRequests(request, requests.Items) >= int(quota.Spec.MaxRequests) {
+			return fmt.Sprintf("RequestQuota %s allows at most %d Requests in namespace %s", quota.Name, quota.Spec.MaxRequests, request.Namespace), nil
+		}
+	}
+
+	return "", nil
+}
+
+// grantedRequests counts the other granted Requests that take up a slot before the given Request.
+// All granted Requests count if the given Request is not granted, otherwise only those created before it.
+func grantedRequests(request *delav1alpha1.Request, requests []delav1alpha1.Request) int {
+	key := types.NamespacedName{Namespace: request.Namespace, Name: request.Name}.String()
+	granted := requestGranted(request)
+	count := 0
+	for i := range requests {
+		r := &requests[i]
+		rKey := types.NamespacedName{Namespace: r.Namespace, Name: r.Name}.String()
+		if rKey == key || r.DeletionTimestamp != nil || !requestGranted(r) {
+			continue
+		}
+
+		if !granted || requestBefore(r, request) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// requestGranted checks if the Request has been granted access to the Intent and has not been denied since.
+// Requests without the Denied condition are granted if they are Ready or Suspended.
+func requestGranted(request *delav1alpha1.Request) bool {
+	if condition := delav1alpha1.GetCondition(request.Status.Conditions, delav1alpha1.RequestConditionDenied); condition != nil {
+		return condition.Status == corev1.ConditionFalse
+	}
+
+	return request.Status.State == delav1alpha1.RequestStateReady || request.Status.State == delav1alpha1.RequestStateSuspended
+}
+
+// requestBefore checks if a Request was created before another Request.
+// Requests with equal creation timestamps are ordered by namespace and name, and a Request that has not been created yet is last.
+func requestBefore(a *delav1alpha1.Request, b *delav1alpha1.Request) bool {
+	if b.CreationTimestamp.IsZero() {
+		return true
+	}
+	if a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		aKey := types.NamespacedName{Namespace: a.Namespace, Name: a.Name}.String()
+		bKey := types.NamespacedName{Namespace: b.Namespace, Name: b.Name}.String()
+		return aKey < bKey
+	}
+
+	return a.CreationTimestamp.Before(&b.CreationTimestamp)
+}
@@ -1,14 +1,19 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -20,25 +25,40 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
 )
 
 var (
-	jobOwnerKey  = ".metadata.controller"
-	intentRefKey = ".metadata.intentRef"
-	apiGVStr     = delav1alpha1.GroupVersion.String()
+	jobOwnerKey   = ".metadata.controller"
+	intentRefKey  = ".metadata.intentRef"
+	secretNameKey = ".spec.secretMetadata.name"
+	apiGVStr      = delav1alpha1.GroupVersion.String()
 )
 
+// pendingRequeueAfter is the longest time to wait before checking a pending dependency again.
+// Changes are normally picked up by the watches, so this only bounds the time when an event is missed.
+const pendingRequeueAfter = 1 * time.Minute
+
 // RequestReconciler reconciles a Request object
 type RequestReconciler struct {
 	client.Client
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// Performs all Secret writes as dry runs.
+	DryRun bool
+	// Receives audit records of all decisions, disabled if nil.
+	Audit audit.Sink
+	// Reads the shared data, defaults to reading Secrets.
+	Sources SecretSources
 }
 
-// +kubebuilder:rbac:groups=delete.phillebaba.io,resources=requests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=requests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dela.phillebaba.io,resources=requests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=requestquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dela.phillebaba.io,resources=sharepolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 func (r *RequestReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
@@ -50,25 +70,60 @@ func (r *RequestReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// Function to update the Status before return
+	// Orphan the Secret copies of a deleted Request with the Orphan deletion policy
+	deleting, err := r.reconcileDeletionPolicy(ctx, request)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if deleting {
+		return ctrl.Result{}, nil
+	}
+
+	previousState := request.Status.State
+	original := request.DeepCopy()
+
+	// Function to patch the Status before return, only when it has changed
+	dryRunActions := []string{}
 	defer func() {
-		if err := r.Status().Update(ctx, request); err != nil {
+		request.Status.Conditions = setDryRunCondition(request.Status.Conditions, r.DryRun, dryRunActions)
+		request.Status.ObservedGeneration = request.Generation
+		if equality.Semantic.DeepEqual(original.Status, request.Status) {
+			return
+		}
+		if err := r.Status().Patch(ctx, request, client.MergeFrom(original)); err != nil {
 			log.Error(err, "Could not update status")
 		}
 	}()
 
+	// Make sure no other Request claims the Secret destination
+	conflict, err := ClaimConflict(ctx, r, request)
+	if err != nil {
+		request.Status.State = delav1alpha1.RequestStateError
+		return ctrl.Result{}, err
+	}
+	if conflict != "" {
+		request.Status.State = delav1alpha1.RequestStateConflict
+		r.Recorder.Event(request, corev1.EventTypeNormal, "Conflict", conflict)
+		r.auditRequestDenied(request, previousState, "Conflict")
+		return ctrl.Result{}, nil
+	}
+
 	// Make sure Secret destination does not already exist
 	existSecret := &corev1.Secret{}
-	err := r.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, existSecret)
+	err = r.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, existSecret)
 	if client.IgnoreNotFound(err) != nil {
 		request.Status.State = delav1alpha1.RequestStateError
 		return ctrl.Result{}, err
 	}
-	if err == nil && existSecret != nil {
-		owner := metav1.GetControllerOf(existSecret)
-		if owner == nil || owner.Kind != "Request" && owner.Name != request.Name {
+	copyExists := err == nil
+	adopt := false
+	if copyExists && !metav1.IsControlledBy(existSecret, request) {
+		// Only Secrets without a controller can be adopted
+		adopt = metav1.GetControllerOf(existSecret) == nil && request.Spec.AdoptionPolicy != "" && request.Spec.AdoptionPolicy != delav1alpha1.AdoptionPolicyNever
+		if !adopt {
 			request.Status.State = delav1alpha1.RequestStateError
 			r.Recorder.Event(request, corev1.EventTypeNormal, "SecretExists", "Destination Secret already exists")
+			r.auditRequestDenied(request, previousState, "SecretExists")
 			return ctrl.Result{}, errors.New("Destination alreay exists")
 		}
 	}
@@ -78,19 +133,21 @@ func (r *RequestReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	intent := &delav1alpha1.Intent{}
 	if err := r.Get(ctx, intentNN, intent); err != nil {
 		if apierrors.IsNotFound(err) {
-			request.Status.State = delav1alpha1.RequestStateError
+			request.Status.State = delav1alpha1.RequestStatePending
 			r.Recorder.Event(request, corev1.EventTypeNormal, "MissingIntent", "Could not find referenced Intent")
+			return ctrl.Result{RequeueAfter: pendingRequeueAfter}, nil
 		}
+		request.Status.State = delav1alpha1.RequestStateError
 		return ctrl.Result{}, err
 	}
 	if intent.Status.State != delav1alpha1.IntentStateReady {
-		request.Status.State = delav1alpha1.RequestStateError
+		request.Status.State = delav1alpha1.RequestStatePending
 		r.Recorder.Event(request, corev1.EventTypeNormal, "IntentNotReady", "Intent not in ready state")
-		return ctrl.Result{}, errors.New("Intent not in ready state")
+		return ctrl.Result{RequeueAfter: pendingRequeueAfter}, nil
 	}
 
 	// Check if Request from namespace is whitelisted
-	matches, err := matchesNamespaceWhitelist(request.Namespace, intent.Spec.NamespaceWhitelist)
+	matches, err := NamespaceWhitelisted(ctx, r, intent, request.Namespace)
 	if err != nil {
 		request.Status.State = delav1alpha1.RequestStateError
 		return ctrl.Result{}, err
@@ -98,55 +155,225 @@ func (r *RequestReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	if matches == false {
 		request.Status.State = delav1alpha1.RequestStateError
 		r.Recorder.Event(request, corev1.EventTypeNormal, "Forbidden", "Intent does not allow request from namespace")
+		r.auditRequestDenied(request, previousState, "Forbidden")
 		return ctrl.Result{}, nil
 	}
 
-	// Get Secret referenced by Intent
-	secretNN := types.NamespacedName{Name: intent.Spec.SecretName, Namespace: intent.Namespace}
-	secret := &corev1.Secret{}
-	if err := r.Get(ctx, secretNN, secret); err != nil {
+	// Check if Request is allowed by SharePolicies
+	violation, err := RequestPolicyViolation(ctx, r, request)
+	if err != nil {
+		request.Status.State = delav1alpha1.RequestStateError
+		return ctrl.Result{}, err
+	}
+	request.Status.Conditions = setPolicyViolationCondition(request.Status.Conditions, violation)
+	if violation != "" {
+		request.Status.State = delav1alpha1.RequestStatePolicyViolation
+		r.Recorder.Event(request, corev1.EventTypeNormal, "PolicyViolation", violation)
+		r.auditRequestDenied(request, previousState, "PolicyViolation")
+		return ctrl.Result{}, nil
+	}
+
+	// Check if Request exceeds any quota
+	quotaMsg, err := QuotaExceeded(ctx, r, request, intent)
+	if err != nil {
+		request.Status.State = delav1alpha1.RequestStateError
+		return ctrl.Result{}, err
+	}
+	if quotaMsg != "" {
+		request.Status.State = delav1alpha1.RequestStateQuotaExceeded
+		r.Recorder.Event(request, corev1.EventTypeNormal, "QuotaExceeded", quotaMsg)
+		r.auditRequestDenied(request, previousState, "QuotaExceeded")
+		return ctrl.Result{}, nil
+	}
+
+	// Get data shared by Intent
+	secretSource, err := r.Sources.For(intent)
+	if err != nil {
+		request.Status.State = delav1alpha1.RequestStateError
+		return ctrl.Result{}, err
+	}
+	secret, err := secretSource.GetSecret(ctx, intent)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			request.Status.State = delav1alpha1.RequestStatePending
+			r.Recorder.Event(request, corev1.EventTypeNormal, "MissingSecret", "Could not find Secret referenced by Intent")
+			return ctrl.Result{RequeueAfter: pendingRequeueAfter}, nil
+		}
+		request.Status.State = delav1alpha1.RequestStateError
+		return ctrl.Result{}, err
+	}
+
+	// Refuse to copy data that does not fulfill the content contract, the Intent may not have observed the change yet
+	reason, message := contractViolation(intent, secret.Data)
+	request.Status.Conditions = setContractViolationCondition(request.Status.Conditions, reason, message)
+	if reason != "" {
+		request.Status.SourceVersion = dataVersion(secret.Data)
+		request.Status.SyncedVersion = ""
+		if copyExists {
+			request.Status.SyncedVersion = dataVersion(existSecret.Data)
+		}
+		setSyncStatus(request, "ContractViolation")
+		request.Status.State = delav1alpha1.RequestStateContractViolation
+		r.Recorder.Event(request, corev1.EventTypeWarning, "ContractViolation", message)
+		r.auditRequestDenied(request, previousState, "ContractViolation")
+		return ctrl.Result{}, nil
+	}
+
+	// Make sure existing Secret can be adopted
+	if adopt && request.Spec.AdoptionPolicy == delav1alpha1.AdoptionPolicyIfIdentical && !secretDataEqual(existSecret.Data, secret.Data) {
+		request.Status.State = delav1alpha1.RequestStateError
+		r.Recorder.Event(request, corev1.EventTypeNormal, "SecretExists", "Destination Secret already exists and differs from source")
+		r.auditRequestDenied(request, previousState, "SecretExists")
+		return ctrl.Result{}, errors.New("Destination already exists and differs from source")
+	}
+
+	// Hold back changes to the Secret copy while suspended or waiting for a manual sync
+	request.Status.SourceVersion = dataVersion(secret.Data)
+	request.Status.SyncedVersion = ""
+	if copyExists {
+		request.Status.SyncedVersion = dataVersion(existSecret.Data)
+	}
+	held, err := rolloutHeld(ctx, r, intent, request, request.Status.SourceVersion)
+	if err != nil {
+		request.Status.State = delav1alpha1.RequestStateError
 		return ctrl.Result{}, err
 	}
+	if reason := syncHoldReason(request, intent, copyExists, held); reason != "" {
+		setSyncStatus(request, reason)
+		request.Status.State = delav1alpha1.RequestStateSuspended
+		if reason == "ManualSync" || reason == "Rollout" {
+			request.Status.State = delav1alpha1.RequestStateReady
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Keep versioned copies and select the version of the Secret copy
+	if request.Spec.Versioning != nil {
+		versionCopy, actions, err := r.reconcileVersions(ctx, request, secret)
+		dryRunActions = append(dryRunActions, actions...)
+		if err != nil {
+			request.Status.State = delav1alpha1.RequestStateError
+			return ctrl.Result{}, err
+		}
+		if versionCopy == nil {
+			request.Status.State = delav1alpha1.RequestStateError
+			r.Recorder.Eventf(request, corev1.EventTypeNormal, "VersionNotFound", "Pinned version %q does not exist", request.Spec.Versioning.Pin)
+			return ctrl.Result{}, nil
+		}
+		secret = versionCopy
+	} else {
+		request.Status.CurrentVersion = ""
+		request.Status.Versions = nil
+	}
+
+	// Detect drift in existing Secret copy
+	if copyExists && !adopt {
+		repair, requeueAfter := r.reconcileDrift(request, existSecret, secret)
+		if !repair {
+			setSyncStatus(request, "Drifted")
+			request.Status.State = delav1alpha1.RequestStateReady
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
 
 	// Create Secret copy
-	secretCopy := &corev1.Secret{ObjectMeta: request.Spec.SecretObjectMeta}
+	secretCopy := &corev1.Secret{ObjectMeta: *request.Spec.SecretObjectMeta.DeepCopy()}
 	secretCopy.ObjectMeta.Namespace = request.Namespace
-	result, err := ctrl.CreateOrUpdate(ctx, r, secretCopy, func() error {
+	mutate := func() error {
 		secretCopy.Data = secret.Data
+		if secretCopy.Annotations == nil {
+			secretCopy.Annotations = map[string]string{}
+		}
+		secretCopy.Annotations[delav1alpha1.SourceResourceVersionAnnotation] = secret.ResourceVersion
+		secretCopy.Annotations[delav1alpha1.DataVersionAnnotation] = dataVersion(secret.Data)
+		if secretCopy.Labels == nil {
+			secretCopy.Labels = map[string]string{}
+		}
+		secretCopy.Labels[delav1alpha1.ManagedLabel] = "true"
 		err := controllerutil.SetControllerReference(request, secretCopy, r.Scheme)
 		return err
-	})
+	}
+	var result controllerutil.OperationResult
+	replace := request.Spec.Immutable || (copyExists && existSecret.Annotations[delav1alpha1.ImmutableAnnotation] == "true")
+	if replace {
+		var existing *corev1.Secret
+		if copyExists {
+			existing = existSecret
+		}
+		result, err = r.replaceSecretCopy(ctx, request, secretCopy, existing, mutate)
+	} else {
+		result, err = ctrl.CreateOrUpdate(ctx, r, secretCopy, mutate)
+	}
 	if err != nil {
 		request.Status.State = delav1alpha1.RequestStateError
 		r.Recorder.Event(request, corev1.EventTypeNormal, "Failed", "Could not create Secret copy")
 		return ctrl.Result{}, err
 	}
+	r.auditRequestGranted(request, previousState, secret)
+	if result != controllerutil.OperationResultNone {
+		dryRunActions = append(dryRunActions, fmt.Sprintf("%s Secret %q", result, secretCopy.Name))
+	}
+	switch {
+	case adopt:
+		r.Recorder.Eventf(request, corev1.EventTypeNormal, "Adopted", "Adopted existing Secret %q", secretCopy.Name)
+		r.auditRequest(request, audit.ActionAdopted, string(request.Spec.AdoptionPolicy), secretCopy.Name, secret)
+	case result == controllerutil.OperationResultCreated:
+		r.auditRequest(request, audit.ActionCreated, "", secretCopy.Name, secret)
+	case result == controllerutil.OperationResultUpdated:
+		r.auditRequest(request, audit.ActionUpdated, "", secretCopy.Name, secret)
+	}
 
-	// Delete Secret copy if SecretObjectMeta has changed name
+	// Delete Secret copy if SecretObjectMeta has changed name, versioned copies are only kept while versioning is enabled
 	var childSecrets corev1.SecretList
 	if err := r.List(ctx, &childSecrets, client.InNamespace(req.Namespace), client.MatchingFields{jobOwnerKey: req.Name}); err != nil {
 		return ctrl.Result{}, err
 	}
 	for _, childSecret := range childSecrets.Items {
+		if request.Spec.Versioning != nil && childSecret.Labels[delav1alpha1.VersionLabel] != "" {
+			continue
+		}
 		if childSecret.Name != request.Spec.SecretObjectMeta.Name {
 			log.Info("Deleting old Secret copy due to name change", "old", childSecret.Name, "new", request.Spec.SecretObjectMeta.Name)
 			if err := r.Delete(ctx, &childSecret); err != nil {
 				return ctrl.Result{}, err
 			}
+			dryRunActions = append(dryRunActions, fmt.Sprintf("deleted Secret %q", childSecret.Name))
+			r.auditRequest(request, audit.ActionDeleted, "NameChanged", childSecret.Name, nil)
 		}
 	}
 
 	// Creation completed sucessfully
+	request.Status.SyncedVersion = dataVersion(secretCopy.Data)
+	request.Status.LastSyncToken = request.Annotations[delav1alpha1.SyncTokenAnnotation]
+	if result != controllerutil.OperationResultNone {
+		now := metav1.Now()
+		request.Status.LastSyncTime = &now
+	}
+	setSyncStatus(request, "Pinned")
 	request.Status.State = delav1alpha1.RequestStateReady
+	if adopt {
+		return ctrl.Result{}, nil
+	}
 	if result == controllerutil.OperationResultCreated {
 		r.Recorder.Eventf(request, corev1.EventTypeNormal, "Created", "Created Secret %q", secretCopy.Name)
-	} else {
+	} else if replace && result == controllerutil.OperationResultUpdated {
+		r.Recorder.Eventf(request, corev1.EventTypeNormal, "Replaced", "Replaced Secret %q", secretCopy.Name)
+	} else if result == controllerutil.OperationResultUpdated {
 		r.Recorder.Eventf(request, corev1.EventTypeNormal, "Updated", "Updated Secret %q", secretCopy.Name)
 	}
 	return ctrl.Result{}, nil
 }
 
 func (r *RequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Client = &secretFallbackClient{Client: r.Client, APIReader: mgr.GetAPIReader()}
+	if r.DryRun {
+		r.Client = &dryRunClient{Client: r.Client}
+		r.Recorder = &dryRunRecorder{EventRecorder: r.Recorder}
+	}
+	if r.Sources.Secret == nil {
+		r.Sources.Secret = &KubernetesSecretSource{Client: r.Client}
+	}
+
 	if err := mgr.GetFieldIndexer().IndexField(&corev1.Secret{}, jobOwnerKey, func(rawObj runtime.Object) []string {
 		secret := rawObj.(*corev1.Secret)
 		owner := metav1.GetControllerOf(secret)
@@ -169,6 +396,13 @@ func (r *RequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(&delav1alpha1.Request{}, secretNameKey, func(rawObj runtime.Object) []string {
+		request := rawObj.(*delav1alpha1.Request)
+		return []string{request.Spec.SecretObjectMeta.Name}
+	}); err != nil {
+		return err
+	}
+
 	secretMapFn := handler.ToRequestsFunc(
 		func(a handler.MapObject) []reconcile.Request {
 			ctx := context.Background()
@@ -220,6 +454,102 @@ func (r *RequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	)
 
+	// Requests that exceed a quota may fit, and conflicting Requests may take the claim, once another Request is removed
+	requestMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			ctx := context.Background()
+			request := a.Object.(*delav1alpha1.Request)
+
+			var namespaceRequests delav1alpha1.RequestList
+			if err := r.List(ctx, &namespaceRequests, client.InNamespace(request.Namespace)); err != nil {
+				return []reconcile.Request{}
+			}
+			var intentRequests delav1alpha1.RequestList
+			nn := types.NamespacedName{Namespace: request.Spec.IntentRef.Namespace, Name: request.Spec.IntentRef.Name}
+			if err := r.List(ctx, &intentRequests, client.MatchingField(intentRefKey, nn.String())); err != nil {
+				return []reconcile.Request{}
+			}
+			reconcileReq := []reconcile.Request{}
+			for _, other := range append(namespaceRequests.Items, intentRequests.Items...) {
+				if other.Status.State != delav1alpha1.RequestStateQuotaExceeded &&
+					!(other.Status.State == delav1alpha1.RequestStateConflict && other.Namespace == request.Namespace) {
+					continue
+				}
+				reconcileReq = append(reconcileReq, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name:      other.Name,
+					Namespace: other.Namespace,
+				}})
+			}
+
+			return reconcileReq
+		},
+	)
+
+	quotaMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			ctx := context.Background()
+			quota := a.Object.(*delav1alpha1.RequestQuota)
+
+			var requests delav1alpha1.RequestList
+			if err := r.List(ctx, &requests); err != nil {
+				return []reconcile.Request{}
+			}
+			reconcileReq := []reconcile.Request{}
+			for _, request := range requests.Items {
+				if matches, err := matchesNamespaceWhitelist(request.Namespace, quota.Spec.Namespaces); err != nil || !matches {
+					continue
+				}
+				reconcileReq = append(reconcileReq, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name:      request.Name,
+					Namespace: request.Namespace,
+				}})
+			}
+
+			return reconcileReq
+		},
+	)
+
+	policyMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			ctx := context.Background()
+
+			var requests delav1alpha1.RequestList
+			if err := r.List(ctx, &requests); err != nil {
+				return []reconcile.Request{}
+			}
+			reconcileReq := []reconcile.Request{}
+			for _, request := range requests.Items {
+				reconcileReq = append(reconcileReq, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name:      request.Name,
+					Namespace: request.Namespace,
+				}})
+			}
+
+			return reconcileReq
+		},
+	)
+
+	// Namespace labels may be matched by the namespace selector of an Intent
+	namespaceMapFn := handler.ToRequestsFunc(
+		func(a handler.MapObject) []reconcile.Request {
+			ctx := context.Background()
+
+			var requests delav1alpha1.RequestList
+			if err := r.List(ctx, &requests, client.InNamespace(a.Meta.GetName())); err != nil {
+				return []reconcile.Request{}
+			}
+			reconcileReq := []reconcile.Request{}
+			for _, request := range requests.Items {
+				reconcileReq = append(reconcileReq, reconcile.Request{NamespacedName: types.NamespacedName{
+					Name:      request.Name,
+					Namespace: request.Namespace,
+				}})
+			}
+
+			return reconcileReq
+		},
+	)
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&delav1alpha1.Request{}).
 		Owns(&corev1.Secret{}).
@@ -231,9 +561,75 @@ func (r *RequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &delav1alpha1.Intent{}},
 			&handler.EnqueueRequestsFromMapFunc{ToRequests: intentMapFn},
 		).
+		Watches(
+			&source.Kind{Type: &delav1alpha1.Request{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: requestMapFn},
+		).
+		Watches(
+			&source.Kind{Type: &delav1alpha1.RequestQuota{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: quotaMapFn},
+		).
+		Watches(
+			&source.Kind{Type: &delav1alpha1.SharePolicy{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: policyMapFn},
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: namespaceMapFn},
+		).
 		Complete(r)
 }
 
+// secretDataEqual checks if two Secrets contain the same keys and values.
+func secretDataEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bV, ok := b[k]; !ok || !bytes.Equal(v, bV) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NamespaceWhitelisted checks if Requests from the namespace are allowed by the namespace whitelist or namespace selector of the Intent.
+func NamespaceWhitelisted(ctx context.Context, c client.Reader, intent *delav1alpha1.Intent, namespace string) (bool, error) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	if intent.Spec.NamespaceSelector != nil {
+		if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+			return false, err
+		}
+	}
+
+	return matchesIntentNamespaces(intent, ns)
+}
+
+// matchesIntentNamespaces checks if a given namespace matches the namespace whitelist or namespace selector of the Intent.
+// All namespaces match if neither is set.
+func matchesIntentNamespaces(intent *delav1alpha1.Intent, namespace *corev1.Namespace) (bool, error) {
+	if len(intent.Spec.NamespaceWhitelist) == 0 && intent.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+
+	if len(intent.Spec.NamespaceWhitelist) > 0 {
+		matches, err := matchesNamespaceWhitelist(namespace.Name, intent.Spec.NamespaceWhitelist)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	if intent.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(intent.Spec.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		return selector.Matches(labels.Set(namespace.Labels)), nil
+	}
+
+	return false, nil
+}
+
 // matchesNamespaceWhitelist checks if a given namespace matches the regex of any of the namespace whitelists
 func matchesNamespaceWhitelist(namespace string, namespaceWhitelist []string) (bool, error) {
 	if len(namespaceWhitelist) == 0 {
@@ -8,9 +8,14 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
 )
 
 var _ = Describe("Request Controller", func() {
@@ -60,6 +65,39 @@ var _ = Describe("Request Controller", func() {
 			))
 		})
 
+		It("Does not write when nothing has changed", func() {
+			secret, intent, request := baseResources(source, dest)
+
+			By("Creating a Secret, Intent and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+				WithTransform(func(e *delav1alpha1.Request) bool { return e.Status.ObservedGeneration == e.Generation }, BeTrue()),
+			))
+
+			By("Reconciling the unchanged Request")
+			counting := &countingClient{Client: k8sClient}
+			reconciler := &RequestReconciler{
+				Client:   counting,
+				Log:      ctrl.Log.WithName("controllers").WithName("Request"),
+				Scheme:   scheme.Scheme,
+				Recorder: counting.Recorder(record.NewFakeRecorder(100)),
+				Sources:  SecretSources{Secret: &KubernetesSecretSource{Client: counting}},
+			}
+			Eventually(func() int32 {
+				counting.Reset()
+				_, err := reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Name: request.Name, Namespace: request.Namespace}})
+				Expect(err).NotTo(HaveOccurred())
+				return counting.Writes()
+			}, timeout, interval).Should(BeZero())
+		})
+
 		It("Triggers an update of a Request from an Intent change", func() {
 			secret, intent, request := baseResources(source, dest)
 
@@ -71,7 +109,7 @@ var _ = Describe("Request Controller", func() {
 				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
 				return r
 			}, timeout, interval).Should(
-				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateError)),
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStatePending)),
 			)
 
 			By("Creating an Intent")
@@ -96,7 +134,7 @@ var _ = Describe("Request Controller", func() {
 				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
 				return r
 			}, timeout, interval).Should(
-				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateError)),
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStatePending)),
 			)
 		})
 
@@ -138,7 +176,7 @@ var _ = Describe("Request Controller", func() {
 				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
 				return r
 			}, timeout, interval).Should(
-				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateError)),
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStatePending)),
 			)
 			Eventually(func() error {
 				secretCopy := &corev1.Secret{}
@@ -165,7 +203,7 @@ var _ = Describe("Request Controller", func() {
 				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
 				return r
 			}, timeout, interval).Should(
-				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateError)),
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStatePending)),
 			)
 			Eventually(func() error {
 				secretCopy := &corev1.Secret{}
@@ -202,6 +240,281 @@ var _ = Describe("Request Controller", func() {
 		})
 	})
 
+	Context("Drifted Secret copy", func() {
+		It("Repairs the Secret copy", func() {
+			secret, intent, request := baseResources(source, dest)
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			secretCopy := &corev1.Secret{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+			}, timeout, interval).Should(Succeed())
+
+			By("Changing the Secret copy data")
+			secretCopy.Data["foo"] = []byte("tampered")
+			Expect(k8sClient.Update(ctx, secretCopy)).Should(Succeed())
+			Eventually(func() *corev1.Secret {
+				secretCopy := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+				return secretCopy
+			}, timeout, interval).Should(
+				WithTransform(func(e *corev1.Secret) []byte { return e.Data["foo"] }, Equal(secret.Data["foo"])),
+			)
+		})
+
+		It("Reports drift without repairing the Secret copy", func() {
+			secret, intent, request := baseResources(source, dest)
+			request.Spec.DriftPolicy = delav1alpha1.DriftPolicyReport
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			secretCopy := &corev1.Secret{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+			}, timeout, interval).Should(Succeed())
+
+			By("Changing the Secret copy data")
+			secretCopy.Data["foo"] = []byte("tampered")
+			Expect(k8sClient.Update(ctx, secretCopy)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Condition {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return delav1alpha1.GetCondition(r.Status.Conditions, delav1alpha1.RequestConditionDrifted)
+			}, timeout, interval).Should(SatisfyAll(
+				Not(BeNil()),
+				WithTransform(func(e *delav1alpha1.Condition) corev1.ConditionStatus { return e.Status }, Equal(corev1.ConditionTrue)),
+				WithTransform(func(e *delav1alpha1.Condition) string { return e.Message }, ContainSubstring("foo")),
+				WithTransform(func(e *delav1alpha1.Condition) string { return e.Message }, Not(ContainSubstring("tampered"))),
+			))
+			Consistently(func() *corev1.Secret {
+				secretCopy := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+				return secretCopy
+			}, time.Second*5, interval).Should(
+				WithTransform(func(e *corev1.Secret) []byte { return e.Data["foo"] }, Equal([]byte("tampered"))),
+			)
+		})
+
+		It("Only skips changes to the source for copies with a matching data version", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{"foo": []byte("baz")}}
+			written := map[string][]byte{"foo": []byte("bar")}
+			secretCopy := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{delav1alpha1.DataVersionAnnotation: dataVersion(written)}},
+				Data:       written,
+			}
+
+			By("Comparing an unchanged copy to a changed source")
+			Expect(driftedKeys(secretCopy, secret)).To(BeEmpty())
+
+			By("Comparing a copy without the data version")
+			delete(secretCopy.Annotations, delav1alpha1.DataVersionAnnotation)
+			Expect(driftedKeys(secretCopy, secret)).To(Equal([]string{"foo"}))
+
+			By("Comparing a copy with a mismatched data version")
+			secretCopy.Annotations[delav1alpha1.DataVersionAnnotation] = dataVersion(secret.Data)
+			Expect(driftedKeys(secretCopy, secret)).To(Equal([]string{"foo"}))
+		})
+	})
+
+	Context("Cluster with quotas", func() {
+		It("Does not fulfill Requests exceeding the Intent max requests", func() {
+			secret, intent, request := baseResources(source, dest)
+			maxRequests := int32(1)
+			intent.Spec.MaxRequests = &maxRequests
+			second := request.DeepCopy()
+			second.Name = "second"
+			second.Spec.SecretObjectMeta.Name = "second-copy"
+
+			By("Creating a Secret, Intent, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}, timeout, interval).Should(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+			)
+
+			By("Creating a second Request for the Intent")
+			Expect(k8sClient.Create(ctx, second)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: second.Name, Namespace: second.Namespace}, r)
+				return r
+			}, timeout, interval).Should(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateQuotaExceeded)),
+			)
+
+			By("Deleting the first Request")
+			Expect(k8sClient.Delete(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: second.Name, Namespace: second.Namespace}, r)
+				return r
+			}, timeout, interval).Should(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+			)
+		})
+
+		It("Does not fulfill Requests exceeding a RequestQuota", func() {
+			secret, intent, request := baseResources(source, dest)
+			quota := &delav1alpha1.RequestQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: dest.Name,
+				},
+				Spec: delav1alpha1.RequestQuotaSpec{
+					Namespaces:  []string{dest.Name},
+					MaxRequests: 0,
+				},
+			}
+
+			By("Creating a RequestQuota, Secret, Intent, and Request")
+			Expect(k8sClient.Create(ctx, quota)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}, timeout, interval).Should(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateQuotaExceeded)),
+			)
+
+			By("Deleting the RequestQuota")
+			Expect(k8sClient.Delete(ctx, quota)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}, timeout, interval).Should(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+			)
+		})
+
+		It("Only counts granted Requests towards quotas", func() {
+			now := metav1.Now()
+			later := metav1.NewTime(now.Add(time.Minute))
+			newRequest := func(name string, created metav1.Time, state delav1alpha1.RequestState, denied corev1.ConditionStatus) delav1alpha1.Request {
+				request := delav1alpha1.Request{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: dest.Name, CreationTimestamp: created},
+					Status:     delav1alpha1.RequestStatus{State: state},
+				}
+				if denied != "" {
+					request.Status.Conditions = delav1alpha1.SetCondition(nil, delav1alpha1.Condition{Type: delav1alpha1.RequestConditionDenied, Status: denied})
+				}
+				return request
+			}
+			requests := []delav1alpha1.Request{
+				newRequest("denied", now, delav1alpha1.RequestStatePolicyViolation, corev1.ConditionTrue),
+				newRequest("error", now, delav1alpha1.RequestStateError, corev1.ConditionTrue),
+				newRequest("pending", now, delav1alpha1.RequestStatePending, ""),
+				newRequest("granted", later, delav1alpha1.RequestStateReady, corev1.ConditionFalse),
+			}
+
+			By("Counting for a new Request")
+			request := newRequest("new", metav1.Time{}, "", "")
+			Expect(grantedRequests(&request, requests)).To(Equal(1))
+
+			By("Counting for a granted Request created before the other granted Request")
+			request = newRequest("first", now, delav1alpha1.RequestStateReady, corev1.ConditionFalse)
+			Expect(grantedRequests(&request, requests)).To(Equal(0))
+
+			By("Counting for a Request granted before the Denied condition was introduced")
+			request = newRequest("legacy", metav1.NewTime(later.Add(time.Minute)), delav1alpha1.RequestStateReady, "")
+			Expect(grantedRequests(&request, requests)).To(Equal(1))
+		})
+	})
+
+	Context("Cluster with SharePolicy", func() {
+		It("Does not fulfill Requests denied by the SharePolicy", func() {
+			secret, intent, request := baseResources(source, dest)
+			policy := &delav1alpha1.SharePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: dest.Name,
+				},
+				Spec: delav1alpha1.SharePolicySpec{
+					Deny: []delav1alpha1.SharePolicyRule{{
+						SourceNamespaces:      []string{source.Name},
+						DestinationNamespaces: []string{dest.Name},
+					}},
+				},
+			}
+
+			By("Creating a SharePolicy, Secret, Intent, and Request")
+			Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}, timeout, interval).Should(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStatePolicyViolation)),
+			)
+
+			By("Deleting the SharePolicy")
+			Expect(k8sClient.Delete(ctx, policy)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}, timeout, interval).Should(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+			)
+		})
+	})
+
+	Context("Cluster with conflicting Requests", func() {
+		It("Only lets the first Request claim the destination Secret", func() {
+			secret, intent, request := baseResources(source, dest)
+			second := request.DeepCopy()
+			second.Name = "second"
+
+			By("Creating a source Secret, Intent, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				sr := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, sr)
+				return sr
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+			))
+
+			By("Creating a second Request for the same Secret")
+			Expect(k8sClient.Create(ctx, second)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				sr := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: second.Name, Namespace: second.Namespace}, sr)
+				return sr
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateConflict)),
+			))
+
+			By("Deleting the first Request and its Secret copy")
+			Expect(k8sClient.Delete(ctx, request)).Should(Succeed())
+			secretCopy := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}}
+			Expect(k8sClient.Delete(ctx, secretCopy)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				sr := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: second.Name, Namespace: second.Namespace}, sr)
+				return sr
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+			))
+		})
+	})
+
 	Context("Cluster with existing secret", func() {
 		var existSecret *corev1.Secret
 		BeforeEach(func() {
@@ -240,6 +553,530 @@ var _ = Describe("Request Controller", func() {
 				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
 			))
 		})
+
+		It("Adopts the existing Secret with the Always adoption policy", func() {
+			secret, intent, request := baseResources(source, dest)
+			request.Spec.SecretObjectMeta.Name = existSecret.Name
+			request.Spec.AdoptionPolicy = delav1alpha1.AdoptionPolicyAlways
+
+			By("Creating a source Secret, Intent, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				sr := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, sr)
+				return sr
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+			))
+			Eventually(func() *corev1.Secret {
+				secretCopy := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: existSecret.Name, Namespace: existSecret.Namespace}, secretCopy)
+				return secretCopy
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *corev1.Secret) []byte { return e.Data["foo"] }, Equal(secret.Data["foo"])),
+				WithTransform(func(e *corev1.Secret) *metav1.OwnerReference { return metav1.GetControllerOf(e) }, Not(BeNil())),
+			))
+		})
+
+		It("Only adopts the existing Secret if identical with the IfIdentical adoption policy", func() {
+			secret, intent, request := baseResources(source, dest)
+			request.Spec.SecretObjectMeta.Name = existSecret.Name
+			request.Spec.AdoptionPolicy = delav1alpha1.AdoptionPolicyIfIdentical
+
+			By("Creating a source Secret, Intent, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				sr := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, sr)
+				return sr
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateError)),
+			))
+
+			By("Making the existing Secret identical to the source")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: existSecret.Name, Namespace: existSecret.Namespace}, existSecret)).Should(Succeed())
+			existSecret.Data = secret.Data
+			Expect(k8sClient.Update(ctx, existSecret)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				sr := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, sr)
+				return sr
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+			))
+		})
+	})
+
+	Context("Immutable Secret copies", func() {
+		It("Replaces the Secret copy when the source changes", func() {
+			secret, intent, request := baseResources(source, dest)
+			request.Spec.Immutable = true
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			secretCopy := &corev1.Secret{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+			}, timeout, interval).Should(Succeed())
+			Expect(secretCopy.Annotations[delav1alpha1.ImmutableAnnotation]).Should(Equal("true"))
+			Expect(secretImmutable(secretCopy)).Should(BeTrue())
+
+			By("Updating the Secret data")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)).Should(Succeed())
+			secret.Data["foo"] = []byte("baz")
+			Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+			Eventually(func() *corev1.Secret {
+				replaced := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, replaced)
+				return replaced
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *corev1.Secret) types.UID { return e.UID }, Not(Equal(secretCopy.UID))),
+				WithTransform(func(e *corev1.Secret) []byte { return e.Data["foo"] }, Equal([]byte("baz"))),
+				WithTransform(secretImmutable, BeTrue()),
+			))
+		})
+
+		It("Replaces a mutable Secret copy when the Request is made immutable", func() {
+			secret, intent, request := baseResources(source, dest)
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			secretCopy := &corev1.Secret{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+			}, timeout, interval).Should(Succeed())
+			Expect(secretImmutable(secretCopy)).Should(BeFalse())
+
+			By("Making the Request immutable")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, request)).Should(Succeed())
+			request.Spec.Immutable = true
+			Expect(k8sClient.Update(ctx, request)).Should(Succeed())
+			Eventually(func() *corev1.Secret {
+				replaced := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, replaced)
+				return replaced
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *corev1.Secret) types.UID { return e.UID }, Not(Equal(secretCopy.UID))),
+				WithTransform(func(e *corev1.Secret) string { return e.Annotations[delav1alpha1.ImmutableAnnotation] }, Equal("true")),
+				WithTransform(func(e *corev1.Secret) []byte { return e.Data["foo"] }, Equal([]byte("bar"))),
+				WithTransform(secretImmutable, BeTrue()),
+			))
+		})
+	})
+
+	Context("Frozen propagation", func() {
+		getSecretCopy := func(request *delav1alpha1.Request) *corev1.Secret {
+			secretCopy := &corev1.Secret{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+			return secretCopy
+		}
+		getRequest := func(request *delav1alpha1.Request) *delav1alpha1.Request {
+			r := &delav1alpha1.Request{}
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+			return r
+		}
+
+		It("Does not update the Secret copy while the Intent is suspended", func() {
+			secret, intent, request := baseResources(source, dest)
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy(request).Data["foo"] }, timeout, interval).Should(Equal([]byte("bar")))
+
+			By("Suspending the Intent and updating the Secret data")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}, intent)).Should(Succeed())
+			intent.Spec.Suspend = true
+			Expect(k8sClient.Update(ctx, intent)).Should(Succeed())
+			Eventually(func() delav1alpha1.RequestState { return getRequest(request).Status.State }, timeout, interval).Should(Equal(delav1alpha1.RequestStateSuspended))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)).Should(Succeed())
+			secret.Data["foo"] = []byte("baz")
+			Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request { return getRequest(request) }, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) *metav1.Time { return e.Status.OutOfSyncSince }, Not(BeNil())),
+				WithTransform(func(e *delav1alpha1.Request) bool { return e.Status.SourceVersion == e.Status.SyncedVersion }, BeFalse()),
+			))
+			Consistently(func() []byte { return getSecretCopy(request).Data["foo"] }, time.Second*5, interval).Should(Equal([]byte("bar")))
+
+			By("Resuming the Intent")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}, intent)).Should(Succeed())
+			intent.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, intent)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy(request).Data["foo"] }, timeout, interval).Should(Equal([]byte("baz")))
+			Eventually(func() *metav1.Time { return getRequest(request).Status.OutOfSyncSince }, timeout, interval).Should(BeNil())
+		})
+
+		It("Only updates the Secret copy when the sync token changes with the Manual sync policy", func() {
+			secret, intent, request := baseResources(source, dest)
+			request.Spec.SyncPolicy = delav1alpha1.SyncPolicyManual
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy(request).Data["foo"] }, timeout, interval).Should(Equal([]byte("bar")))
+
+			By("Updating the Secret data")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)).Should(Succeed())
+			secret.Data["foo"] = []byte("baz")
+			Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Condition {
+				return delav1alpha1.GetCondition(getRequest(request).Status.Conditions, delav1alpha1.RequestConditionOutOfSync)
+			}, timeout, interval).Should(SatisfyAll(
+				Not(BeNil()),
+				WithTransform(func(e *delav1alpha1.Condition) string { return e.Reason }, Equal("ManualSync")),
+			))
+			Consistently(func() []byte { return getSecretCopy(request).Data["foo"] }, time.Second*5, interval).Should(Equal([]byte("bar")))
+
+			By("Changing the sync token")
+			request = getRequest(request)
+			request.Annotations = map[string]string{delav1alpha1.SyncTokenAnnotation: "1"}
+			Expect(k8sClient.Update(ctx, request)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy(request).Data["foo"] }, timeout, interval).Should(Equal([]byte("baz")))
+			Eventually(func() string { return getRequest(request).Status.LastSyncToken }, timeout, interval).Should(Equal("1"))
+		})
+	})
+
+	Context("Staged rollout", func() {
+		canary := SetupTestNamespace(ctx)
+
+		It("Updates Requests in waves by namespace label", func() {
+			secret, intent, request := baseResources(source, dest)
+			canaryRequest := request.DeepCopy()
+			canaryRequest.Namespace = canary.Name
+			intent.Spec.Rollout = &delav1alpha1.RolloutStrategy{
+				NamespaceLabel: "stage",
+				Stages:         []string{"canary"},
+				Pause:          &metav1.Duration{Duration: time.Hour},
+			}
+			getSecretCopy := func(request *delav1alpha1.Request) []byte {
+				secretCopy := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+				return secretCopy.Data["foo"]
+			}
+			getRollout := func() *delav1alpha1.RolloutStatus {
+				i := &delav1alpha1.Intent{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}, i)
+				return i.Status.Rollout
+			}
+
+			By("Labeling the canary namespace")
+			canary.Labels = map[string]string{"stage": "canary"}
+			Expect(k8sClient.Update(ctx, canary)).Should(Succeed())
+
+			By("Creating an Intent, Secret, and Requests")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, canaryRequest)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy(request) }, timeout, interval).Should(Equal([]byte("bar")))
+			Eventually(func() []byte { return getSecretCopy(canaryRequest) }, timeout, interval).Should(Equal([]byte("bar")))
+			Eventually(getRollout, timeout, interval).Should(SatisfyAll(
+				Not(BeNil()),
+				WithTransform(func(e *delav1alpha1.RolloutStatus) delav1alpha1.RolloutPhase { return e.Phase }, Equal(delav1alpha1.RolloutPhaseComplete)),
+			))
+
+			By("Updating the Secret data")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)).Should(Succeed())
+			secret.Data["foo"] = []byte("baz")
+			Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy(canaryRequest) }, timeout, interval).Should(Equal([]byte("baz")))
+			Eventually(getRollout, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.RolloutStatus) delav1alpha1.RolloutPhase { return e.Phase }, Equal(delav1alpha1.RolloutPhaseProgressing)),
+				WithTransform(func(e *delav1alpha1.RolloutStatus) int32 { return e.UpdatedRequests }, Equal(int32(1))),
+				WithTransform(func(e *delav1alpha1.RolloutStatus) int32 { return e.Waves }, Equal(int32(2))),
+			))
+			Consistently(func() []byte { return getSecretCopy(request) }, time.Second*5, interval).Should(Equal([]byte("bar")))
+
+			By("Shortening the pause")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}, intent)).Should(Succeed())
+			intent.Spec.Rollout.Pause = &metav1.Duration{Duration: time.Second}
+			Expect(k8sClient.Update(ctx, intent)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy(request) }, timeout, interval).Should(Equal([]byte("baz")))
+			Eventually(getRollout, timeout, interval).Should(
+				WithTransform(func(e *delav1alpha1.RolloutStatus) delav1alpha1.RolloutPhase { return e.Phase }, Equal(delav1alpha1.RolloutPhaseComplete)),
+			)
+		})
+	})
+
+	Context("Staged rollout with denied Requests", func() {
+		canary := SetupTestNamespace(ctx)
+		denied := SetupTestNamespace(ctx)
+
+		It("Does not wait for denied Requests", func() {
+			secret, intent, request := baseResources(source, dest)
+			canaryRequest := request.DeepCopy()
+			canaryRequest.Namespace = canary.Name
+			deniedRequest := request.DeepCopy()
+			deniedRequest.Namespace = denied.Name
+			quota := &delav1alpha1.RequestQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: denied.Name},
+				Spec: delav1alpha1.RequestQuotaSpec{
+					Namespaces:  []string{denied.Name},
+					MaxRequests: 0,
+				},
+			}
+			intent.Spec.Rollout = &delav1alpha1.RolloutStrategy{
+				NamespaceLabel: "stage",
+				Stages:         []string{"canary"},
+				Pause:          &metav1.Duration{Duration: time.Second},
+			}
+			getRollout := func() *delav1alpha1.RolloutStatus {
+				i := &delav1alpha1.Intent{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}, i)
+				return i.Status.Rollout
+			}
+
+			By("Labeling the canary namespaces")
+			for _, ns := range []*corev1.Namespace{canary, denied} {
+				ns.Labels = map[string]string{"stage": "canary"}
+				Expect(k8sClient.Update(ctx, ns)).Should(Succeed())
+			}
+
+			By("Creating a RequestQuota, Intent, Secret, and Requests")
+			Expect(k8sClient.Create(ctx, quota)).Should(Succeed())
+			defer func() {
+				Expect(k8sClient.Delete(ctx, quota)).Should(Succeed())
+			}()
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, canaryRequest)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, deniedRequest)).Should(Succeed())
+			Eventually(func() delav1alpha1.RequestState {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: deniedRequest.Name, Namespace: deniedRequest.Namespace}, r)
+				return r.Status.State
+			}, timeout, interval).Should(Equal(delav1alpha1.RequestStateQuotaExceeded))
+			Eventually(getRollout, timeout, interval).Should(SatisfyAll(
+				Not(BeNil()),
+				WithTransform(func(e *delav1alpha1.RolloutStatus) delav1alpha1.RolloutPhase { return e.Phase }, Equal(delav1alpha1.RolloutPhaseComplete)),
+			))
+
+			By("Updating the Secret data")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)).Should(Succeed())
+			secret.Data["foo"] = []byte("baz")
+			Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+			Eventually(func() []byte {
+				secretCopy := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+				return secretCopy.Data["foo"]
+			}, timeout, interval).Should(Equal([]byte("baz")))
+			Eventually(getRollout, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.RolloutStatus) delav1alpha1.RolloutPhase { return e.Phase }, Equal(delav1alpha1.RolloutPhaseComplete)),
+				WithTransform(func(e *delav1alpha1.RolloutStatus) int32 { return e.UpdatedRequests }, Equal(int32(2))),
+			))
+		})
+	})
+
+	Context("Versioned Secret copies", func() {
+		It("Keeps versions and rolls back to the previous one", func() {
+			secret, intent, request := baseResources(source, dest)
+			keep := int32(2)
+			request.Spec.Versioning = &delav1alpha1.Versioning{Keep: &keep}
+			getRequest := func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}
+			getSecretCopy := func() *corev1.Secret {
+				secretCopy := &corev1.Secret{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)
+				return secretCopy
+			}
+			updateSecret := func(value string) {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)).Should(Succeed())
+				secret.Data["foo"] = []byte(value)
+				Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+				Eventually(func() []byte { return getSecretCopy().Data["foo"] }, timeout, interval).Should(Equal([]byte(value)))
+			}
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy().Data["foo"] }, timeout, interval).Should(Equal([]byte("bar")))
+			firstVersion := dataVersion(map[string][]byte{"foo": []byte("bar")})
+			versionCopy := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: versionedSecretName(request, firstVersion), Namespace: request.Namespace}, versionCopy)).Should(Succeed())
+			Expect(versionCopy.Data["foo"]).Should(Equal([]byte("bar")))
+
+			By("Updating the Secret data twice")
+			updateSecret("baz")
+			updateSecret("qux")
+			Eventually(func() []string { return getRequest().Status.Versions }, timeout, interval).Should(Equal([]string{
+				dataVersion(map[string][]byte{"foo": []byte("qux")}),
+				dataVersion(map[string][]byte{"foo": []byte("baz")}),
+			}))
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: versionedSecretName(request, firstVersion), Namespace: request.Namespace}, &corev1.Secret{})
+			}, timeout, interval).ShouldNot(Succeed())
+
+			By("Rolling back to the previous version")
+			request = getRequest()
+			request.Annotations = map[string]string{delav1alpha1.RollbackAnnotation: "true"}
+			Expect(k8sClient.Update(ctx, request)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy().Data["foo"] }, timeout, interval).Should(Equal([]byte("baz")))
+			Eventually(func() string { return getRequest().Status.RolledBackTo }, timeout, interval).Should(Equal(dataVersion(map[string][]byte{"foo": []byte("baz")})))
+			Expect(getRequest().Spec.Versioning.Pin).Should(BeEmpty())
+			Expect(getRequest().Annotations).ShouldNot(HaveKey(delav1alpha1.RollbackAnnotation))
+
+			By("Updating the Secret data while pinned")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)).Should(Succeed())
+			secret.Data["foo"] = []byte("quux")
+			Expect(k8sClient.Update(ctx, secret)).Should(Succeed())
+			Consistently(func() []byte { return getSecretCopy().Data["foo"] }, time.Second*5, interval).Should(Equal([]byte("baz")))
+
+			By("Changing the Request spec")
+			request = getRequest()
+			keep = 3
+			request.Spec.Versioning.Keep = &keep
+			Expect(k8sClient.Update(ctx, request)).Should(Succeed())
+			Eventually(func() []byte { return getSecretCopy().Data["foo"] }, timeout, interval).Should(Equal([]byte("quux")))
+			Expect(getRequest().Status.RolledBackTo).Should(BeEmpty())
+		})
+
+		It("Creates immutable versioned copies", func() {
+			secret, intent, request := baseResources(source, dest)
+			request.Spec.Versioning = &delav1alpha1.Versioning{}
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			versionCopy := &corev1.Secret{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: versionedSecretName(request, dataVersion(secret.Data)), Namespace: request.Namespace}, versionCopy)
+			}, timeout, interval).Should(Succeed())
+			Expect(secretImmutable(versionCopy)).Should(BeTrue())
+		})
+	})
+
+	Context("Audit", func() {
+		It("Records grants, copies and revocations once", func() {
+			secret, intent, request := baseResources(source, dest)
+			intent.Spec.NamespaceWhitelist = []string{dest.Name}
+			getRequest := func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() delav1alpha1.RequestState { return getRequest().Status.State }, timeout, interval).Should(Equal(delav1alpha1.RequestStateReady))
+			Eventually(func() []audit.Action { return auditSink.Actions(request) }, timeout, interval).Should(Equal([]audit.Action{audit.ActionGranted, audit.ActionCreated}))
+
+			By("Reconciling the Request without changes")
+			request = getRequest()
+			request.Annotations = map[string]string{"reconcile": "true"}
+			Expect(k8sClient.Update(ctx, request)).Should(Succeed())
+			Consistently(func() []audit.Action { return auditSink.Actions(request) }, time.Second*5, interval).Should(Equal([]audit.Action{audit.ActionGranted, audit.ActionCreated}))
+
+			By("Removing the namespace from the whitelist")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}, intent)).Should(Succeed())
+			intent.Spec.NamespaceWhitelist = []string{source.Name}
+			Expect(k8sClient.Update(ctx, intent)).Should(Succeed())
+			Eventually(func() []audit.Action { return auditSink.Actions(request) }, timeout, interval).Should(Equal([]audit.Action{audit.ActionGranted, audit.ActionCreated, audit.ActionRevoked}))
+
+			By("Reconciling the denied Request without changes")
+			request = getRequest()
+			request.Annotations = map[string]string{"reconcile": "false"}
+			Expect(k8sClient.Update(ctx, request)).Should(Succeed())
+			Consistently(func() []audit.Action { return auditSink.Actions(request) }, time.Second*5, interval).Should(HaveLen(3))
+		})
+
+		It("Records denials when the reason changes", func() {
+			sink := &recordingSink{}
+			r := &RequestReconciler{Log: ctrl.Log.WithName("controllers").WithName("Request"), Audit: sink}
+			request := &delav1alpha1.Request{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: dest.Name}}
+			deny := func(reason string) {
+				previousState := request.Status.State
+				request.Status.State = delav1alpha1.RequestStateError
+				r.auditRequestDenied(request, previousState, reason)
+			}
+
+			By("Denying the Request twice for the same reason")
+			deny("Forbidden")
+			deny("Forbidden")
+			Expect(sink.Actions(request)).To(Equal([]audit.Action{audit.ActionDenied}))
+
+			By("Denying the Request for another reason in the same state")
+			deny("SecretExists")
+			Expect(sink.Actions(request)).To(Equal([]audit.Action{audit.ActionDenied, audit.ActionDenied}))
+			Expect(delav1alpha1.GetCondition(request.Status.Conditions, delav1alpha1.RequestConditionDenied).Reason).To(Equal("SecretExists"))
+
+			By("Granting the Request twice")
+			r.auditRequestGranted(request, request.Status.State, nil)
+			request.Status.State = delav1alpha1.RequestStateReady
+			r.auditRequestGranted(request, request.Status.State, nil)
+			Expect(sink.Actions(request)).To(Equal([]audit.Action{audit.ActionDenied, audit.ActionDenied, audit.ActionGranted}))
+		})
+	})
+
+	Context("Deletion policy", func() {
+		It("Orphans the Secret copy when the Request is deleted", func() {
+			secret, intent, request := baseResources(source, dest)
+			request.Spec.DeletionPolicy = delav1alpha1.DeletionPolicyOrphan
+
+			By("Creating an Intent, Secret, and Request")
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+			Eventually(func() *delav1alpha1.Request {
+				r := &delav1alpha1.Request{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, r)
+				return r
+			}, timeout, interval).Should(SatisfyAll(
+				WithTransform(func(e *delav1alpha1.Request) delav1alpha1.RequestState { return e.Status.State }, Equal(delav1alpha1.RequestStateReady)),
+				WithTransform(func(e *delav1alpha1.Request) []string { return e.Finalizers }, ContainElement(delav1alpha1.RequestOrphanFinalizer)),
+			))
+			secretCopy := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)).Should(Succeed())
+			Expect(metav1.IsControlledBy(secretCopy, request)).Should(BeTrue())
+
+			By("Deleting the Request")
+			Expect(k8sClient.Delete(ctx, request)).Should(Succeed())
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, &delav1alpha1.Request{})
+			}, timeout, interval).ShouldNot(Succeed())
+
+			By("Checking that the Secret copy is orphaned")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: request.Spec.SecretObjectMeta.Name, Namespace: request.Namespace}, secretCopy)).Should(Succeed())
+			Expect(secretCopy.OwnerReferences).Should(BeEmpty())
+			Expect(secretCopy.Annotations).ShouldNot(HaveKey(delav1alpha1.SourceResourceVersionAnnotation))
+			Expect(secretCopy.Labels).ShouldNot(HaveKey(delav1alpha1.ManagedLabel))
+			Expect(secretCopy.Data["foo"]).Should(Equal([]byte("bar")))
+		})
+
+		It("Does not add the finalizer in dry run", func() {
+			_, _, request := baseResources(source, dest)
+			key := types.NamespacedName{Name: request.Name, Namespace: request.Namespace}
+			r := &RequestReconciler{Client: &dryRunClient{Client: k8sClient}, DryRun: true}
+
+			By("Creating a Request")
+			Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+
+			By("Reconciling the orphan deletion policy in dry run")
+			Expect(k8sClient.Get(ctx, key, request)).Should(Succeed())
+			request.Spec.DeletionPolicy = delav1alpha1.DeletionPolicyOrphan
+			deleting, err := r.reconcileDeletionPolicy(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleting).To(BeFalse())
+			Expect(k8sClient.Get(ctx, key, request)).Should(Succeed())
+			Expect(request.Finalizers).ShouldNot(ContainElement(delav1alpha1.RequestOrphanFinalizer))
+		})
 	})
 })
 
@@ -282,3 +1119,12 @@ func baseResources(source *corev1.Namespace, dest *corev1.Namespace) (*corev1.Se
 
 	return secret, intent, request
 }
+
+// Checks if the Secret is immutable, the field is read from the unstructured Secret as it is not part of the Secret type.
+func secretImmutable(secret *corev1.Secret) bool {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, u)).Should(Succeed())
+	immutable, _, _ := unstructured.NestedBool(u.Object, "immutable")
+	return immutable
+}
@@ -0,0 +1,302 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// defaultRolloutPause is the time between waves when the rollout strategy does not set it.
+const defaultRolloutPause = 5 * time.Minute
+
+// rolloutPollInterval is how often a rollout waiting for consuming Pods is checked again.
+const rolloutPollInterval = 30 * time.Second
+
+// rolloutWaves assigns each Request of the Intent to a wave according to the rollout strategy.
+// Returns the wave of each Request by namespaced name, the number of waves, and the Requests.
+func rolloutWaves(ctx context.Context, c client.Client, intent *delav1alpha1.Intent) (map[string]int, int, []delav1alpha1.Request, error) {
+	var requestList delav1alpha1.RequestList
+	nn := types.NamespacedName{Namespace: intent.Namespace, Name: intent.Name}
+	if err := c.List(ctx, &requestList, client.MatchingFields{intentRefKey: nn.String()}); err != nil {
+		return nil, 0, nil, err
+	}
+	requests := []delav1alpha1.Request{}
+	for _, request := range requestList.Items {
+		if request.DeletionTimestamp == nil {
+			requests = append(requests, request)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requestKey(&requests[i]) < requestKey(&requests[j])
+	})
+
+	strategy := intent.Spec.Rollout
+	waves := map[string]int{}
+	count := 0
+	if strategy.NamespaceLabel != "" {
+		namespaces := map[string]*corev1.Namespace{}
+		for i := range requests {
+			ns, ok := namespaces[requests[i].Namespace]
+			if !ok {
+				ns = &corev1.Namespace{}
+				if err := c.Get(ctx, types.NamespacedName{Name: requests[i].Namespace}, ns); err != nil {
+					return nil, 0, nil, err
+				}
+				namespaces[requests[i].Namespace] = ns
+			}
+
+			wave := len(strategy.Stages)
+			for j, stage := range strategy.Stages {
+				if ns.Labels[strategy.NamespaceLabel] == stage {
+					wave = j
+					break
+				}
+			}
+			waves[requestKey(&requests[i])] = wave
+		}
+	} else {
+		for i := range requests {
+			wave := len(strategy.Percentages)
+			for j, percentage := range strategy.Percentages {
+				// Round up so that each wave with a percentage above zero contains at least one Request
+				if i < (int(percentage)*len(requests)+99)/100 {
+					wave = j
+					break
+				}
+			}
+			waves[requestKey(&requests[i])] = wave
+		}
+	}
+	for _, wave := range waves {
+		if wave+1 > count {
+			count = wave + 1
+		}
+	}
+
+	return waves, count, requests, nil
+}
+
+// requestKey returns the namespaced name of the Request.
+func requestKey(request *delav1alpha1.Request) string {
+	return types.NamespacedName{Namespace: request.Namespace, Name: request.Name}.String()
+}
+
+// rolloutHeld checks if the rollout of the Intent holds back the given version from the Request.
+// Requests are held back until the Intent has started the rollout of the version and has reached the wave of the Request.
+// Waves are not stored but recomputed from the current Requests, so Requests created or deleted during the rollout
+// can move other Requests between percentage waves, and relabeling a namespace moves its Requests between stages.
+func rolloutHeld(ctx context.Context, c client.Client, intent *delav1alpha1.Intent, request *delav1alpha1.Request, version string) (bool, error) {
+	if intent.Spec.Rollout == nil || intent.Status.Rollout == nil {
+		return false, nil
+	}
+	if intent.Status.Rollout.Version != version {
+		return true, nil
+	}
+	if intent.Status.Rollout.Phase == delav1alpha1.RolloutPhaseComplete {
+		return false, nil
+	}
+
+	waves, _, _, err := rolloutWaves(ctx, c, intent)
+	if err != nil {
+		return false, err
+	}
+	wave, ok := waves[requestKey(request)]
+	if !ok {
+		// Requests that are not listed yet are only updated once the rollout is complete
+		return true, nil
+	}
+
+	return wave > int(intent.Status.Rollout.Wave), nil
+}
+
+// reconcileRollout progresses the rollout of the shared Secret wave by wave.
+// Returns the duration after which the Intent should be reconciled again.
+func (r *IntentReconciler) reconcileRollout(ctx context.Context, intent *delav1alpha1.Intent, secret *corev1.Secret) (time.Duration, error) {
+	strategy := intent.Spec.Rollout
+	version := dataVersion(secret.Data)
+	now := metav1.Now()
+
+	status := intent.Status.Rollout
+	switch {
+	case status == nil:
+		// Copies made before the first observed version are not rolled out
+		status = &delav1alpha1.RolloutStatus{Version: version, Phase: delav1alpha1.RolloutPhaseComplete}
+		intent.Status.Rollout = status
+	case status.Version != version:
+		status = &delav1alpha1.RolloutStatus{Version: version, Phase: delav1alpha1.RolloutPhaseProgressing, WaveStartTime: &now}
+		intent.Status.Rollout = status
+		r.Recorder.Eventf(intent, corev1.EventTypeNormal, "RolloutStarted", "Rolling out version %s", version)
+	}
+
+	waves, count, requests, err := rolloutWaves(ctx, r, intent)
+	if err != nil {
+		return 0, err
+	}
+	status.Waves = int32(count)
+	status.TotalRequests = int32(len(requests))
+	status.UpdatedRequests = 0
+	for _, request := range requests {
+		if request.Status.SyncedVersion == version {
+			status.UpdatedRequests++
+		}
+	}
+	if status.Phase != delav1alpha1.RolloutPhaseProgressing {
+		return 0, nil
+	}
+
+	// Wait for all Requests in the current and earlier waves to be updated, except those that will not be updated by the rollout
+	done := true
+	failure := ""
+	for _, request := range requests {
+		if waves[requestKey(&request)] > int(status.Wave) {
+			continue
+		}
+		if request.Status.State == delav1alpha1.RequestStateError {
+			failure = fmt.Sprintf("Request %s failed in wave %d", requestKey(&request), status.Wave+1)
+			continue
+		}
+		if !rolloutGated(&request) {
+			continue
+		}
+		if request.Status.SyncedVersion != version {
+			done = false
+		}
+	}
+	if failure != "" && strategy.HaltOnFailure {
+		r.haltRollout(intent, failure)
+		return 0, nil
+	}
+	if !done {
+		status.Message = fmt.Sprintf("Updating wave %d of %d", status.Wave+1, count)
+		return pendingRequeueAfter, nil
+	}
+	if int(status.Wave)+1 >= count {
+		status.Phase = delav1alpha1.RolloutPhaseComplete
+		status.Message = ""
+		r.Recorder.Eventf(intent, corev1.EventTypeNormal, "RolloutComplete", "Rolled out version %s to all Requests", version)
+		return 0, nil
+	}
+
+	pause := defaultRolloutPause
+	if strategy.Pause != nil {
+		pause = strategy.Pause.Duration
+	}
+	if remaining := pause - now.Sub(status.WaveStartTime.Time); remaining > 0 {
+		status.Message = fmt.Sprintf("Pausing after wave %d of %d", status.Wave+1, count)
+		return remaining, nil
+	}
+
+	if strategy.WaitForWorkloads {
+		for _, request := range requests {
+			if waves[requestKey(&request)] > int(status.Wave) {
+				continue
+			}
+			ready, message, err := workloadsReady(ctx, r.APIReader, &request)
+			if err != nil {
+				return 0, err
+			}
+			if ready {
+				continue
+			}
+			if strategy.HaltOnFailure {
+				r.haltRollout(intent, message)
+				return 0, nil
+			}
+			status.Message = message
+			return rolloutPollInterval, nil
+		}
+	}
+
+	status.Wave++
+	status.WaveStartTime = &now
+	status.Message = fmt.Sprintf("Updating wave %d of %d", status.Wave+1, count)
+	r.Recorder.Eventf(intent, corev1.EventTypeNormal, "RolloutWave", "Rolling out version %s to wave %d of %d", version, status.Wave+1, count)
+	return 0, nil
+}
+
+// rolloutGated checks if the rollout waits for the Request to be updated before starting the next wave.
+// Requests that are denied access, only sync on their own terms, or keep a drifted Secret copy are not waited for.
+func rolloutGated(request *delav1alpha1.Request) bool {
+	if request.Status.State != delav1alpha1.RequestStateReady || request.Spec.Suspend || request.Spec.SyncPolicy == delav1alpha1.SyncPolicyManual {
+		return false
+	}
+	condition := delav1alpha1.GetCondition(request.Status.Conditions, delav1alpha1.RequestConditionDrifted)
+	if condition != nil && condition.Status == corev1.ConditionTrue && condition.Reason != "Repaired" {
+		return false
+	}
+
+	return true
+}
+
+// haltRollout stops the rollout so that no more Requests are updated until the shared Secret changes.
+func (r *IntentReconciler) haltRollout(intent *delav1alpha1.Intent, message string) {
+	intent.Status.Rollout.Phase = delav1alpha1.RolloutPhaseHalted
+	intent.Status.Rollout.Message = message
+	r.Recorder.Event(intent, corev1.EventTypeWarning, "RolloutHalted", message)
+}
+
+// workloadsReady checks if all Pods in the namespace of the Request that consume the Secret copy are ready.
+// Pods are read from the API server rather than the cache, so that Pods of all namespaces are not watched.
+// Returns a message naming a Pod that is not ready.
+func workloadsReady(ctx context.Context, c client.Reader, request *delav1alpha1.Request) (bool, string, error) {
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.InNamespace(request.Namespace)); err != nil {
+		return false, "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil || !podConsumesSecret(&pod, request.Spec.SecretObjectMeta.Name) {
+			continue
+		}
+		ready := false
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				ready = true
+			}
+		}
+		if !ready {
+			return false, fmt.Sprintf("Pod %s/%s consuming Secret %q is not ready", pod.Namespace, pod.Name, request.Spec.SecretObjectMeta.Name), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// podConsumesSecret checks if the Pod mounts the Secret or reads environment variables from it.
+func podConsumesSecret(pod *corev1.Pod, name string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == name {
+			return true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil && source.Secret.Name == name {
+					return true
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == name {
+				return true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
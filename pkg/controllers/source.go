@@ -0,0 +1,317 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
+)
+
+// defaultRefreshInterval is how often data from outside the cluster is read when the Intent does not set a refresh interval.
+const defaultRefreshInterval = 5 * time.Minute
+
+// maxHTTPSourceSize is the largest response body accepted from an HTTP source.
+const maxHTTPSourceSize = 1 << 20
+
+// SecretSource reads the data that is shared by an Intent.
+type SecretSource interface {
+	// GetSecret returns the shared data as a Secret, or a NotFound error if the data does not exist.
+	// The resource version of the Secret changes every time the data changes.
+	GetSecret(ctx context.Context, intent *delav1alpha1.Intent) (*corev1.Secret, error)
+}
+
+// SecretSources selects the SecretSource used to read the data of each Intent.
+// A source kind is disabled if its SecretSource is nil.
+type SecretSources struct {
+	// Reads Secrets referenced by secretName.
+	Secret SecretSource
+	// Reads files and directories referenced by source.file.
+	File SecretSource
+	// Reads HTTP endpoints referenced by source.http.
+	HTTP SecretSource
+}
+
+// For returns the SecretSource for the Intent.
+func (s SecretSources) For(intent *delav1alpha1.Intent) (SecretSource, error) {
+	if msg := InvalidIntentSource(intent); msg != "" {
+		return nil, errors.New(msg)
+	}
+
+	var source SecretSource
+	kind := "secret"
+	switch {
+	case intent.Spec.Source == nil:
+		source = s.Secret
+	case intent.Spec.Source.File != nil:
+		source, kind = s.File, "file"
+	case intent.Spec.Source.HTTP != nil:
+		source, kind = s.HTTP, "http"
+	}
+	if source == nil {
+		return nil, fmt.Errorf("%s source is not enabled", kind)
+	}
+
+	return source, nil
+}
+
+// InvalidIntentSource checks that the Intent references exactly one source of data.
+// Returns a message describing the problem, or an empty string if the source is valid.
+func InvalidIntentSource(intent *delav1alpha1.Intent) string {
+	if intent.Spec.Source == nil {
+		if intent.Spec.SecretName == "" {
+			return "Intent has to set either secretName or source"
+		}
+		return ""
+	}
+	if intent.Spec.SecretName != "" {
+		return "Intent can not set both secretName and source"
+	}
+	if (intent.Spec.Source.File == nil) == (intent.Spec.Source.HTTP == nil) {
+		return "Intent source has to set exactly one of file or http"
+	}
+
+	return ""
+}
+
+// refreshInterval returns how often data from outside the cluster should be read for the Intent.
+func refreshInterval(intent *delav1alpha1.Intent) time.Duration {
+	if intent.Spec.Source == nil || intent.Spec.Source.RefreshInterval == nil || intent.Spec.Source.RefreshInterval.Duration <= 0 {
+		return defaultRefreshInterval
+	}
+
+	return intent.Spec.Source.RefreshInterval.Duration
+}
+
+// sourceSecret wraps data read from outside the cluster in a Secret.
+// The content hash is used as resource version so that changes to the data are detected.
+func sourceSecret(intent *delav1alpha1.Intent, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            intent.Name,
+			Namespace:       intent.Namespace,
+			ResourceVersion: audit.ContentHash(data),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+// KubernetesSecretSource reads the Secret referenced by the Intent from the namespace of the Intent.
+type KubernetesSecretSource struct {
+	Client client.Client
+}
+
+func (s *KubernetesSecretSource) GetSecret(ctx context.Context, intent *delav1alpha1.Intent) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Name: intent.Spec.SecretName, Namespace: intent.Namespace}, secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// FileSecretSource reads files from a directory, usually a volume mounted in the controller.
+// Each namespace has its own directory in the root named after the namespace, so that Intents can only read the files
+// of their own namespace. Paths are always resolved inside of the namespace directory, also when following symlinks.
+type FileSecretSource struct {
+	Root string
+}
+
+func (s *FileSecretSource) GetSecret(ctx context.Context, intent *delav1alpha1.Intent) (*corev1.Secret, error) {
+	root, err := filepath.EvalSymlinks(s.Root)
+	if err != nil {
+		return nil, err
+	}
+	root = filepath.Join(root, intent.Namespace)
+	path, err := s.resolve(root, filepath.Join(root, filepath.Clean("/"+intent.Spec.Source.File.Path)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "files"}, intent.Spec.Source.File.Path)
+		}
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string][]byte{}
+	if !info.IsDir() {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data[filepath.Base(path)] = b
+		return sourceSecret(intent, data), nil
+	}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		// Skip hidden files, such as the timestamped directories of mounted Secret and ConfigMap volumes
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		// Follow symlinks, as mounted volume files are symlinks
+		filePath, err := s.resolve(root, filepath.Join(path, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		b, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		data[file.Name()] = b
+	}
+
+	return sourceSecret(intent, data), nil
+}
+
+// resolve follows any symlinks in the path, and makes sure that the result is inside of the namespace directory.
+func (s *FileSecretSource) resolve(root, path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	if resolved != root && !strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q resolves outside of the file source directory of the namespace", path)
+	}
+
+	return resolved, nil
+}
+
+// HTTPSecretSource reads a JSON object of string values from an HTTP endpoint.
+// Only URLs starting with one of the allowed prefixes can be read, prefixes should end with a slash
+// so that they can not be extended to another host.
+// Responses are cached for the refresh interval of the Intent, so that reconciling the Requests of an Intent does not read
+// the endpoint again. The cache is bypassed when the Intent has observed data that differs from the cached response.
+type HTTPSecretSource struct {
+	Client      *http.Client
+	AllowedURLs []string
+
+	mu    sync.Mutex
+	cache map[string]httpSourceResponse
+}
+
+// httpSourceResponse is a cached response from an HTTP source.
+type httpSourceResponse struct {
+	data    map[string][]byte
+	version string
+	time    time.Time
+}
+
+func (s *HTTPSecretSource) GetSecret(ctx context.Context, intent *delav1alpha1.Intent) (*corev1.Secret, error) {
+	url := intent.Spec.Source.HTTP.URL
+	if !s.allowed(url) {
+		return nil, fmt.Errorf("URL %q is not allowed by the http source", url)
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[url]
+	s.mu.Unlock()
+	if ok && time.Since(cached.time) < refreshInterval(intent) && (intent.Status.SourceVersion == "" || intent.Status.SourceVersion == cached.version) {
+		data := make(map[string][]byte, len(cached.data))
+		for k, v := range cached.data {
+			data[k] = v
+		}
+		return sourceSecret(intent, data), nil
+	}
+
+	data, err := s.read(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	secret := sourceSecret(intent, data)
+	s.mu.Lock()
+	if s.cache == nil {
+		s.cache = map[string]httpSourceResponse{}
+	}
+	s.cache[url] = httpSourceResponse{data: data, version: secret.ResourceVersion, time: time.Now()}
+	s.mu.Unlock()
+
+	return secret, nil
+}
+
+// read requests the data from the URL.
+func (s *HTTPSecretSource) read(ctx context.Context, url string) (map[string][]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	httpClient := http.Client{}
+	if s.Client != nil {
+		httpClient = *s.Client
+	}
+	// Redirects could otherwise lead to URLs that are not allowed
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !s.allowed(req.URL.String()) {
+			return fmt.Errorf("redirect to URL %q is not allowed by the http source", req.URL.String())
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "urls"}, url)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("http source %q responded with status %d", url, resp.StatusCode)
+	}
+
+	values := map[string]string{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxHTTPSourceSize)).Decode(&values); err != nil {
+		return nil, fmt.Errorf("could not decode response from http source %q: %v", url, err)
+	}
+	data := map[string][]byte{}
+	for k, v := range values {
+		data[k] = []byte(v)
+	}
+
+	return data, nil
+}
+
+// allowed checks if the URL starts with any of the allowed prefixes.
+func (s *HTTPSecretSource) allowed(url string) bool {
+	for _, prefix := range s.AllowedURLs {
+		if prefix != "" && strings.HasPrefix(url, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+var _ = Describe("Secret Sources", func() {
+	ctx := context.TODO()
+
+	Context("File", func() {
+		var root, dir string
+		BeforeEach(func() {
+			var err error
+			root, err = ioutil.TempDir("", "dela-file-source")
+			Expect(err).NotTo(HaveOccurred())
+			dir = filepath.Join(root, "default")
+			Expect(os.Mkdir(dir, 0700)).Should(Succeed())
+		})
+		AfterEach(func() {
+			Expect(os.RemoveAll(root)).Should(Succeed())
+		})
+
+		fileIntent := func(path string) *delav1alpha1.Intent {
+			return &delav1alpha1.Intent{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+				Spec: delav1alpha1.IntentSpec{
+					Source: &delav1alpha1.IntentSource{File: &delav1alpha1.FileSource{Path: path}},
+				},
+			}
+		}
+
+		It("Reads a file as a single key", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, "token"), []byte("foo"), 0600)).Should(Succeed())
+
+			source := &FileSecretSource{Root: root}
+			secret, err := source.GetSecret(ctx, fileIntent("token"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).Should(Equal(map[string][]byte{"token": []byte("foo")}))
+			Expect(secret.ResourceVersion).ShouldNot(BeEmpty())
+		})
+
+		It("Reads each file in a directory as a key", func() {
+			creds := filepath.Join(dir, "creds")
+			Expect(os.MkdirAll(filepath.Join(creds, "..data"), 0700)).Should(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(creds, "..data", "password"), []byte("bar"), 0600)).Should(Succeed())
+			Expect(os.Symlink(filepath.Join("..data", "password"), filepath.Join(creds, "password"))).Should(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(creds, "username"), []byte("foo"), 0600)).Should(Succeed())
+
+			source := &FileSecretSource{Root: root}
+			secret, err := source.GetSecret(ctx, fileIntent("creds"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).Should(Equal(map[string][]byte{"username": []byte("foo"), "password": []byte("bar")}))
+
+			By("Changing a file")
+			version := secret.ResourceVersion
+			Expect(ioutil.WriteFile(filepath.Join(creds, "username"), []byte("baz"), 0600)).Should(Succeed())
+			secret, err = source.GetSecret(ctx, fileIntent("creds"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.ResourceVersion).ShouldNot(Equal(version))
+		})
+
+		It("Does not follow symlinks outside of the root", func() {
+			outside, err := ioutil.TempDir("", "dela-file-source-outside")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(outside)
+			Expect(ioutil.WriteFile(filepath.Join(outside, "token"), []byte("foo"), 0600)).Should(Succeed())
+			Expect(os.Symlink(filepath.Join(outside, "token"), filepath.Join(dir, "token"))).Should(Succeed())
+			Expect(os.Symlink(outside, filepath.Join(dir, "creds"))).Should(Succeed())
+
+			source := &FileSecretSource{Root: root}
+			_, err = source.GetSecret(ctx, fileIntent("token"))
+			Expect(err).To(HaveOccurred())
+			_, err = source.GetSecret(ctx, fileIntent("creds"))
+			Expect(err).To(HaveOccurred())
+
+			By("Linking a file in a directory")
+			linked := filepath.Join(dir, "dir")
+			Expect(os.Mkdir(linked, 0700)).Should(Succeed())
+			Expect(os.Symlink(filepath.Join(outside, "token"), filepath.Join(linked, "token"))).Should(Succeed())
+			_, err = source.GetSecret(ctx, fileIntent("dir"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Does not read files of other namespaces", func() {
+			other := filepath.Join(root, "other")
+			Expect(os.Mkdir(other, 0700)).Should(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(other, "token"), []byte("foo"), 0600)).Should(Succeed())
+			Expect(os.Symlink(filepath.Join(other, "token"), filepath.Join(dir, "link"))).Should(Succeed())
+
+			source := &FileSecretSource{Root: root}
+			_, err := source.GetSecret(ctx, fileIntent("../other/token"))
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+			_, err = source.GetSecret(ctx, fileIntent("link"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Does not read outside of the root", func() {
+			outside, err := ioutil.TempDir("", "dela-file-source-outside")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(outside)
+			Expect(ioutil.WriteFile(filepath.Join(outside, "token"), []byte("foo"), 0600)).Should(Succeed())
+
+			rel, err := filepath.Rel(dir, filepath.Join(outside, "token"))
+			Expect(err).NotTo(HaveOccurred())
+			source := &FileSecretSource{Root: root}
+			_, err = source.GetSecret(ctx, fileIntent(rel))
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		})
+	})
+
+	Context("HTTP", func() {
+		var server *httptest.Server
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/creds":
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"username":"foo","password":"bar"}`))
+				case "/broken":
+					w.WriteHeader(http.StatusInternalServerError)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		httpIntent := func(url string) *delav1alpha1.Intent {
+			return &delav1alpha1.Intent{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+				Spec: delav1alpha1.IntentSpec{
+					Source: &delav1alpha1.IntentSource{HTTP: &delav1alpha1.HTTPSource{URL: url}},
+				},
+			}
+		}
+
+		It("Reads a JSON object", func() {
+			source := &HTTPSecretSource{Client: server.Client(), AllowedURLs: []string{server.URL + "/"}}
+			secret, err := source.GetSecret(ctx, httpIntent(server.URL+"/creds"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).Should(Equal(map[string][]byte{"username": []byte("foo"), "password": []byte("bar")}))
+
+			By("Requesting a missing object")
+			_, err = source.GetSecret(ctx, httpIntent(server.URL+"/missing"))
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+
+			By("Requesting a failing endpoint")
+			_, err = source.GetSecret(ctx, httpIntent(server.URL+"/broken"))
+			Expect(err).To(HaveOccurred())
+			Expect(apierrors.IsNotFound(err)).Should(BeFalse())
+		})
+
+		It("Caches responses for the refresh interval", func() {
+			requests := 0
+			counting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"count":"%d"}`, requests)))
+			}))
+			defer counting.Close()
+
+			source := &HTTPSecretSource{Client: counting.Client(), AllowedURLs: []string{counting.URL + "/"}}
+			intent := httpIntent(counting.URL + "/creds")
+			secret, err := source.GetSecret(ctx, intent)
+			Expect(err).NotTo(HaveOccurred())
+			intent.Status.SourceVersion = secret.ResourceVersion
+			_, err = source.GetSecret(ctx, intent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(1))
+
+			By("Observing other data in the Intent status")
+			intent.Status.SourceVersion = "other"
+			_, err = source.GetSecret(ctx, intent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(2))
+
+			By("Passing the refresh interval")
+			intent.Status.SourceVersion = ""
+			intent.Spec.Source.RefreshInterval = &metav1.Duration{Duration: time.Nanosecond}
+			_, err = source.GetSecret(ctx, intent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(3))
+		})
+
+		It("Does not follow redirects to URLs that are not allowed", func() {
+			redirect := httptest.NewServer(http.RedirectHandler(server.URL+"/creds", http.StatusFound))
+			defer redirect.Close()
+
+			source := &HTTPSecretSource{Client: redirect.Client(), AllowedURLs: []string{redirect.URL + "/"}}
+			_, err := source.GetSecret(ctx, httpIntent(redirect.URL+"/creds"))
+			Expect(err).To(HaveOccurred())
+
+			By("Allowing the redirect target")
+			source = &HTTPSecretSource{Client: redirect.Client(), AllowedURLs: []string{redirect.URL + "/", server.URL + "/"}}
+			secret, err := source.GetSecret(ctx, httpIntent(redirect.URL+"/creds"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).Should(HaveKey("username"))
+		})
+
+		It("Only reads allowed URLs", func() {
+			source := &HTTPSecretSource{Client: server.Client(), AllowedURLs: []string{"https://example.com/"}}
+			_, err := source.GetSecret(ctx, httpIntent(server.URL+"/creds"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("Selects the source for the Intent", func() {
+		sources := SecretSources{Secret: &KubernetesSecretSource{Client: k8sClient}}
+		intent := &delav1alpha1.Intent{Spec: delav1alpha1.IntentSpec{SecretName: "main"}}
+		Expect(sources.For(intent)).Should(Equal(sources.Secret))
+
+		By("Using a disabled source")
+		intent.Spec.SecretName = ""
+		intent.Spec.Source = &delav1alpha1.IntentSource{HTTP: &delav1alpha1.HTTPSource{URL: "https://example.com/"}}
+		_, err := sources.For(intent)
+		Expect(err).To(HaveOccurred())
+	})
+})
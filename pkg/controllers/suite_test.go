@@ -4,6 +4,8 @@ import (
 	"context"
 	"math/rand"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,8 +14,10 @@ import (
 	"github.com/onsi/gomega/gexec"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -24,6 +28,7 @@ import (
 	// +kubebuilder:scaffold:imports
 
 	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
 )
 
 // These tests use Ginkgo (BDD-style Go testing framework). Refer to
@@ -32,6 +37,7 @@ import (
 var cfg *rest.Config
 var k8sClient client.Client
 var testEnv *envtest.Environment
+var auditSink = &recordingSink{}
 
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -67,6 +73,7 @@ var _ = BeforeSuite(func(done Done) {
 		Log:      ctrl.Log.WithName("controllers").WithName("Request"),
 		Scheme:   k8sManager.GetScheme(),
 		Recorder: k8sManager.GetEventRecorderFor("request-controller"),
+		Audit:    auditSink,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -75,6 +82,31 @@ var _ = BeforeSuite(func(done Done) {
 		Log:      ctrl.Log.WithName("controllers").WithName("Intent"),
 		Scheme:   k8sManager.GetScheme(),
 		Recorder: k8sManager.GetEventRecorderFor("intent-controller"),
+		Audit:    auditSink,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&AnnotationReconciler{
+		Client:   k8sManager.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Annotation"),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("annotation-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ImportReconciler{
+		Client:   k8sManager.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Import"),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("import-controller"),
+		Formats:  []string{ImportFormatKubed, ImportFormatReflector},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&IntentCatalogReconciler{
+		Client: k8sManager.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("IntentCatalog"),
+		Scheme: k8sManager.GetScheme(),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -131,3 +163,114 @@ func randStringRunes(n int) string {
 	}
 	return string(b)
 }
+
+// countingClient counts the writes made through the client, including writes to the status and events.
+type countingClient struct {
+	client.Client
+	writes int32
+}
+
+func (c *countingClient) Writes() int32 {
+	return atomic.LoadInt32(&c.writes)
+}
+
+func (c *countingClient) Reset() {
+	atomic.StoreInt32(&c.writes, 0)
+}
+
+func (c *countingClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	atomic.AddInt32(&c.writes, 1)
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *countingClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	atomic.AddInt32(&c.writes, 1)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *countingClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	atomic.AddInt32(&c.writes, 1)
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *countingClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	atomic.AddInt32(&c.writes, 1)
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *countingClient) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+	atomic.AddInt32(&c.writes, 1)
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *countingClient) Status() client.StatusWriter {
+	return &countingStatusWriter{StatusWriter: c.Client.Status(), writes: &c.writes}
+}
+
+// countingStatusWriter counts the writes to the status of objects.
+type countingStatusWriter struct {
+	client.StatusWriter
+	writes *int32
+}
+
+func (w *countingStatusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	atomic.AddInt32(w.writes, 1)
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (w *countingStatusWriter) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	atomic.AddInt32(w.writes, 1)
+	return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// Recorder wraps the recorder so that events are counted as writes made through the client.
+func (c *countingClient) Recorder(recorder record.EventRecorder) record.EventRecorder {
+	return &countingRecorder{EventRecorder: recorder, writes: &c.writes}
+}
+
+// countingRecorder counts the events that are recorded.
+type countingRecorder struct {
+	record.EventRecorder
+	writes *int32
+}
+
+func (r *countingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	atomic.AddInt32(r.writes, 1)
+	r.EventRecorder.Event(object, eventtype, reason, message)
+}
+
+func (r *countingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	atomic.AddInt32(r.writes, 1)
+	r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func (r *countingRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	atomic.AddInt32(r.writes, 1)
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+}
+
+// recordingSink records all audit records in memory.
+type recordingSink struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (s *recordingSink) Write(record audit.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+// Actions returns the actions of the records for the Request.
+func (s *recordingSink) Actions(request *delav1alpha1.Request) []audit.Action {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	actions := []audit.Action{}
+	for _, record := range s.records {
+		if record.Request == requestKey(request) {
+			actions = append(actions, record.Action)
+		}
+	}
+	return actions
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+// syncHoldReason returns why changes to the Secret copy are held back, or an empty string if the Secret copy can be synced.
+// A Request with the Manual sync policy is synced when the Secret copy does not exist or when the sync token has changed,
+// while a Request held back by a rollout is synced when the rollout reaches its wave.
+func syncHoldReason(request *delav1alpha1.Request, intent *delav1alpha1.Intent, copyExists bool, rolloutHeld bool) string {
+	switch {
+	case request.Spec.Suspend:
+		return "Suspended"
+	case intent.Spec.Suspend:
+		return "IntentSuspended"
+	case rolloutHeld && copyExists:
+		return "Rollout"
+	case request.Spec.SyncPolicy == delav1alpha1.SyncPolicyManual && copyExists && request.Annotations[delav1alpha1.SyncTokenAnnotation] == request.Status.LastSyncToken:
+		return "ManualSync"
+	}
+
+	return ""
+}
+
+// setSyncStatus records how far the Secret copy is behind the source, with the reason for the difference.
+func setSyncStatus(request *delav1alpha1.Request, reason string) {
+	if request.Status.SyncedVersion == request.Status.SourceVersion {
+		request.Status.OutOfSyncSince = nil
+		request.Status.Conditions = delav1alpha1.SetCondition(request.Status.Conditions, delav1alpha1.Condition{
+			Type:   delav1alpha1.RequestConditionOutOfSync,
+			Status: corev1.ConditionFalse,
+			Reason: "InSync",
+		})
+		return
+	}
+
+	if request.Status.OutOfSyncSince == nil {
+		now := metav1.Now()
+		request.Status.OutOfSyncSince = &now
+	}
+	message := fmt.Sprintf("Secret copy is behind the source since %s", request.Status.OutOfSyncSince.Format(time.RFC3339))
+	if request.Status.SyncedVersion != "" {
+		message = fmt.Sprintf("Secret copy has version %s while the source has version %s since %s", request.Status.SyncedVersion, request.Status.SourceVersion, request.Status.OutOfSyncSince.Format(time.RFC3339))
+	}
+	request.Status.Conditions = delav1alpha1.SetCondition(request.Status.Conditions, delav1alpha1.Condition{
+		Type:    delav1alpha1.RequestConditionOutOfSync,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+}
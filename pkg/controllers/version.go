@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/audit"
+)
+
+// defaultKeepVersions is the number of versions kept when the Request does not set it.
+const defaultKeepVersions = 3
+
+// versionLength is the number of characters of the content hash used as version.
+const versionLength = 10
+
+// dataVersion returns the version of the data.
+func dataVersion(data map[string][]byte) string {
+	return audit.ContentHash(data)[:versionLength]
+}
+
+// versionedSecretName returns the name of the versioned copy of the Secret for the version.
+func versionedSecretName(request *delav1alpha1.Request, version string) string {
+	return fmt.Sprintf("%s-%s", request.Spec.SecretObjectMeta.Name, version)
+}
+
+// reconcileVersions makes sure the current version of the source Secret has a versioned copy, and removes versions that are no longer kept.
+// Versioned copies are created immutable and are never updated, as their name is derived from their content.
+// Returns the versioned copy that the Secret copy should contain, which is nil if the pinned version does not exist.
+func (r *RequestReconciler) reconcileVersions(ctx context.Context, request *delav1alpha1.Request, secret *corev1.Secret) (*corev1.Secret, []string, error) {
+	actions := []string{}
+
+	// Create versioned copy of the current source data
+	version := dataVersion(secret.Data)
+	versionCopy := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: versionedSecretName(request, version), Namespace: request.Namespace}, versionCopy)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, actions, err
+	}
+	if apierrors.IsNotFound(err) {
+		conflict, err := versionClaimConflict(ctx, r, request, versionedSecretName(request, version))
+		if err != nil {
+			return nil, actions, err
+		}
+		if conflict != "" {
+			return nil, actions, errors.New(conflict)
+		}
+		versionCopy = &corev1.Secret{ObjectMeta: *request.Spec.SecretObjectMeta.DeepCopy(), Data: secret.Data}
+		versionCopy.Name = versionedSecretName(request, version)
+		versionCopy.Namespace = request.Namespace
+		if versionCopy.Labels == nil {
+			versionCopy.Labels = map[string]string{}
+		}
+		versionCopy.Labels[delav1alpha1.RequestLabel] = request.Name
+		versionCopy.Labels[delav1alpha1.VersionLabel] = version
+		versionCopy.Labels[delav1alpha1.ManagedLabel] = "true"
+		if err := controllerutil.SetControllerReference(request, versionCopy, r.Scheme); err != nil {
+			return nil, actions, err
+		}
+		if err := createImmutableSecret(ctx, r.Client, versionCopy); err != nil {
+			return nil, actions, err
+		}
+		actions = append(actions, fmt.Sprintf("created Secret %q", versionCopy.Name))
+		r.Recorder.Eventf(request, corev1.EventTypeNormal, "Versioned", "Created Secret version %q", versionCopy.Name)
+		r.auditRequest(request, audit.ActionCreated, "Versioned", versionCopy.Name, secret)
+	} else if !metav1.IsControlledBy(versionCopy, request) {
+		return nil, actions, fmt.Errorf("Secret %q already exists and is not a version of the Request", versionCopy.Name)
+	}
+
+	var copies corev1.SecretList
+	if err := r.List(ctx, &copies, client.InNamespace(request.Namespace), client.MatchingLabels{delav1alpha1.RequestLabel: request.Name}); err != nil {
+		return nil, actions, err
+	}
+	existing := map[string]*corev1.Secret{}
+	for i := range copies.Items {
+		c := &copies.Items[i]
+		if c.Labels[delav1alpha1.VersionLabel] == "" || !metav1.IsControlledBy(c, request) {
+			continue
+		}
+		existing[c.Labels[delav1alpha1.VersionLabel]] = c
+	}
+	existing[version] = versionCopy
+	versions := orderVersions(version, request.Status.Versions, existing)
+
+	// A rollback holds until the spec is changed, such as by pinning a version
+	if request.Generation != request.Status.ObservedGeneration {
+		request.Status.RolledBackTo = ""
+	}
+
+	// Roll back to the version before the current one when a rollback is requested
+	if _, ok := request.Annotations[delav1alpha1.RollbackAnnotation]; ok {
+		previous := ""
+		for i, v := range versions {
+			if v == request.Status.CurrentVersion && i+1 < len(versions) {
+				previous = versions[i+1]
+			}
+		}
+		// The annotation is removed even in a dry run, so that the rollback is only done once
+		handled := request.DeepCopy()
+		delete(handled.Annotations, delav1alpha1.RollbackAnnotation)
+		if err := withoutDryRun(r.Client).Patch(ctx, handled, client.MergeFrom(request)); err != nil {
+			return nil, actions, err
+		}
+		delete(request.Annotations, delav1alpha1.RollbackAnnotation)
+		if previous == "" {
+			r.Recorder.Eventf(request, corev1.EventTypeWarning, "RollbackFailed", "No version before %q to roll back to", request.Status.CurrentVersion)
+		} else {
+			request.Status.RolledBackTo = previous
+			r.Recorder.Eventf(request, corev1.EventTypeNormal, "RolledBack", "Rolled back Secret copy to version %q", previous)
+		}
+	}
+
+	target := version
+	if request.Spec.Versioning.Pin != "" {
+		target = request.Spec.Versioning.Pin
+	} else if _, ok := existing[request.Status.RolledBackTo]; ok {
+		target = request.Status.RolledBackTo
+	}
+	targetCopy, ok := existing[target]
+	if !ok {
+		return nil, actions, nil
+	}
+
+	// Remove versions that are no longer kept, the pinned version is always kept
+	keep := defaultKeepVersions
+	if request.Spec.Versioning.Keep != nil {
+		keep = int(*request.Spec.Versioning.Keep)
+	}
+	kept := []string{}
+	for i, v := range versions {
+		if i < keep || v == target {
+			kept = append(kept, v)
+			continue
+		}
+		if err := r.Delete(ctx, existing[v]); client.IgnoreNotFound(err) != nil {
+			return nil, actions, err
+		}
+		actions = append(actions, fmt.Sprintf("deleted Secret %q", existing[v].Name))
+		r.auditRequest(request, audit.ActionDeleted, "VersionPruned", existing[v].Name, nil)
+	}
+
+	request.Status.Versions = kept
+	request.Status.CurrentVersion = target
+	return targetCopy, actions, nil
+}
+
+// orderVersions orders the existing versions newest first, starting with the latest version.
+// The order of previously known versions is kept, and unknown versions are ordered by creation time.
+func orderVersions(latest string, known []string, existing map[string]*corev1.Secret) []string {
+	versions := []string{latest}
+	seen := map[string]bool{latest: true}
+	for _, v := range known {
+		if _, ok := existing[v]; ok && !seen[v] {
+			versions = append(versions, v)
+			seen[v] = true
+		}
+	}
+
+	unknown := []string{}
+	for v := range existing {
+		if !seen[v] {
+			unknown = append(unknown, v)
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		a, b := existing[unknown[i]], existing[unknown[j]]
+		if a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return unknown[i] < unknown[j]
+		}
+		return b.CreationTimestamp.Before(&a.CreationTimestamp)
+	})
+
+	return append(versions, unknown...)
+}
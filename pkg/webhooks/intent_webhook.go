@@ -0,0 +1,67 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/controllers"
+)
+
+// +kubebuilder:webhook:path=/validate-dela-phillebaba-io-v1alpha1-intent,mutating=false,failurePolicy=fail,groups=dela.phillebaba.io,resources=intents,verbs=create;update,versions=v1alpha1,name=vintent.dela.phillebaba.io
+
+// IntentValidator validates Intents before they are admitted
+type IntentValidator struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+func (v *IntentValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	intent := &delav1alpha1.Intent{}
+	if err := v.decoder.Decode(req, intent); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if intent.Namespace == "" {
+		intent.Namespace = req.Namespace
+	}
+
+	// Changes to the metadata of an existing Intent, like removing finalizers, are not checked again.
+	// Otherwise an Intent that violates a SharePolicy added after it was created could never be deleted.
+	if req.Operation == admissionv1beta1.Update {
+		if intent.DeletionTimestamp != nil {
+			return admission.Allowed("")
+		}
+		old := &delav1alpha1.Intent{}
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(old.Spec, intent.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	if msg := controllers.InvalidIntentSource(intent); msg != "" {
+		return admission.Denied(msg)
+	}
+
+	violation, err := controllers.IntentPolicyViolation(ctx, v.Client, intent)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if violation != "" {
+		return admission.Denied(violation)
+	}
+
+	return admission.Allowed("")
+}
+
+// InjectDecoder injects the decoder into the IntentValidator
+func (v *IntentValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
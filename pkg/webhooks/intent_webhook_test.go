@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+var _ = Describe("Intent Webhook", func() {
+	const timeout = time.Second * 30
+	const interval = time.Second * 1
+
+	ctx := context.TODO()
+	ns := SetupTestNamespace(ctx)
+
+	var validator *IntentValidator
+	BeforeEach(func() {
+		validator = &IntentValidator{Client: k8sClient}
+		Expect(validator.InjectDecoder(decoder)).Should(Succeed())
+	})
+
+	It("Denies Intents without exactly one source", func() {
+		intent := &delav1alpha1.Intent{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: ns.Name},
+		}
+		Expect(validator.Handle(ctx, admissionRequest(intent)).Allowed).Should(BeFalse())
+
+		intent.Spec.SecretName = "main"
+		intent.Spec.Source = &delav1alpha1.IntentSource{File: &delav1alpha1.FileSource{Path: "main"}}
+		Expect(validator.Handle(ctx, admissionRequest(intent)).Allowed).Should(BeFalse())
+
+		intent.Spec.SecretName = ""
+		Expect(validator.Handle(ctx, admissionRequest(intent)).Allowed).Should(BeTrue())
+	})
+
+	It("Denies Intents in namespaces not selected by a SharePolicy", func() {
+		intent := &delav1alpha1.Intent{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: ns.Name},
+			Spec:       delav1alpha1.IntentSpec{SecretName: "main"},
+		}
+		policy := &delav1alpha1.SharePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: ns.Name},
+			Spec: delav1alpha1.SharePolicySpec{
+				IntentNamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"shareable": ns.Name},
+				},
+			},
+		}
+
+		By("Creating a SharePolicy")
+		Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(intent)).Allowed
+		}, timeout, interval).Should(BeFalse())
+
+		By("Labeling the namespace")
+		ns.Labels = map[string]string{"shareable": ns.Name}
+		Expect(k8sClient.Update(ctx, ns)).Should(Succeed())
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(intent)).Allowed
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, policy)).Should(Succeed())
+	})
+
+	It("Allows metadata changes to Intents that violate a SharePolicy", func() {
+		intent := &delav1alpha1.Intent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "main",
+				Namespace:  ns.Name,
+				Finalizers: []string{delav1alpha1.IntentProtectionFinalizer},
+			},
+			Spec: delav1alpha1.IntentSpec{SecretName: "main"},
+		}
+		policy := &delav1alpha1.SharePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: ns.Name},
+			Spec: delav1alpha1.SharePolicySpec{
+				Deny: []delav1alpha1.SharePolicyRule{{SourceNamespaces: []string{ns.Name}}},
+			},
+		}
+
+		By("Creating a SharePolicy")
+		Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(intent)).Allowed
+		}, timeout, interval).Should(BeFalse())
+
+		By("Removing the finalizer")
+		updated := intent.DeepCopy()
+		updated.Finalizers = nil
+		Expect(validator.Handle(ctx, updateAdmissionRequest(intent, updated)).Allowed).Should(BeTrue())
+
+		By("Changing the spec")
+		updated.Spec.SecretName = "other"
+		Expect(validator.Handle(ctx, updateAdmissionRequest(intent, updated)).Allowed).Should(BeFalse())
+
+		By("Deleting the Intent")
+		now := metav1.Now()
+		updated.DeletionTimestamp = &now
+		Expect(validator.Handle(ctx, updateAdmissionRequest(intent, updated)).Allowed).Should(BeTrue())
+
+		Expect(k8sClient.Delete(ctx, policy)).Should(Succeed())
+	})
+})
@@ -0,0 +1,267 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/controllers"
+)
+
+// +kubebuilder:webhook:path=/mutate-v1-pod,mutating=true,failurePolicy=fail,groups="",resources=pods,verbs=create,versions=v1,name=mpod.dela.phillebaba.io
+
+// PodInjector injects shared Secrets into Pods annotated with an Intent reference.
+// A Request for the Intent is created in the namespace of the Pod if it does not already exist, except for dry run requests.
+// Only Pods with the inject label are sent to the webhook, which is configured in config/webhook/pod_webhook_patch.yaml.
+type PodInjector struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+func (i *PodInjector) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pod := &corev1.Pod{}
+	if err := i.decoder.Decode(req, pod); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if pod.Namespace == "" {
+		pod.Namespace = req.Namespace
+	}
+
+	ref, ok := pod.Annotations[delav1alpha1.InjectIntentAnnotation]
+	if !ok {
+		return admission.Allowed("")
+	}
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return admission.Denied(fmt.Sprintf("Annotation %s must be in the format namespace/name", delav1alpha1.InjectIntentAnnotation))
+	}
+	intentNN := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+
+	mode := delav1alpha1.InjectionMode(pod.Annotations[delav1alpha1.InjectModeAnnotation])
+	if mode == "" {
+		mode = delav1alpha1.InjectionModeVolume
+	}
+	if mode != delav1alpha1.InjectionModeVolume && mode != delav1alpha1.InjectionModeEnvFrom {
+		return admission.Denied(fmt.Sprintf("Annotation %s must be either %s or %s", delav1alpha1.InjectModeAnnotation, delav1alpha1.InjectionModeVolume, delav1alpha1.InjectionModeEnvFrom))
+	}
+
+	containers, err := injectContainers(pod)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	// Make sure the Pod namespace has access to the Intent
+	intent := &delav1alpha1.Intent{}
+	if err := i.Client.Get(ctx, intentNN, intent); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Denied(fmt.Sprintf("Intent %s does not exist", intentNN))
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if intent.Status.State != delav1alpha1.IntentStateReady {
+		return admission.Denied(fmt.Sprintf("Intent %s is not ready", intentNN))
+	}
+	whitelisted, err := controllers.NamespaceWhitelisted(ctx, i.Client, intent, pod.Namespace)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !whitelisted {
+		return admission.Denied(fmt.Sprintf("Intent %s does not allow requests from namespace %s", intentNN, pod.Namespace))
+	}
+
+	request, exists, err := i.getRequest(ctx, pod.Namespace, intent)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if request.Spec.IntentRef.Namespace != intent.Namespace || request.Spec.IntentRef.Name != intent.Name {
+		return admission.Denied(fmt.Sprintf("Request %s/%s already exists for a different Intent", request.Namespace, request.Name))
+	}
+	// An existing Request that can not be fulfilled would leave the Pod waiting for a Secret copy that never appears
+	switch request.Status.State {
+	case "", delav1alpha1.RequestStatePending, delav1alpha1.RequestStateReady, delav1alpha1.RequestStateSuspended:
+	default:
+		return admission.Denied(fmt.Sprintf("Request %s/%s is in the %s state", request.Namespace, request.Name, request.Status.State))
+	}
+	for _, check := range []func() (string, error){
+		func() (string, error) { return controllers.RequestPolicyViolation(ctx, i.Client, request) },
+		func() (string, error) { return controllers.ClaimConflict(ctx, i.Client, request) },
+		func() (string, error) { return controllers.QuotaExceeded(ctx, i.Client, request, intent) },
+	} {
+		msg, err := check()
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if msg != "" {
+			return admission.Denied(msg)
+		}
+	}
+	if !exists && (req.DryRun == nil || !*req.DryRun) {
+		if err := i.Client.Create(ctx, request); err != nil && !apierrors.IsAlreadyExists(err) {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+
+	secretName := request.Spec.SecretObjectMeta.Name
+	switch mode {
+	case delav1alpha1.InjectionModeVolume:
+		mountPath := pod.Annotations[delav1alpha1.InjectMountPathAnnotation]
+		if mountPath == "" {
+			mountPath = path.Join("/var/run/secrets/dela", intent.Name)
+		}
+		injectVolume(pod, containers, secretName, mountPath)
+	case delav1alpha1.InjectionModeEnvFrom:
+		injectEnvFrom(pod, containers, secretName)
+	}
+
+	marshaledPod, err := json.Marshal(pod)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
+}
+
+// InjectDecoder injects the decoder into the PodInjector
+func (i *PodInjector) InjectDecoder(d *admission.Decoder) error {
+	i.decoder = d
+	return nil
+}
+
+// getRequest gets the Request for the Intent in the namespace, or returns a new Request if it does not exist.
+// Returns if the Request already exists.
+func (i *PodInjector) getRequest(ctx context.Context, namespace string, intent *delav1alpha1.Intent) (*delav1alpha1.Request, bool, error) {
+	name := injectRequestName(types.NamespacedName{Namespace: intent.Namespace, Name: intent.Name})
+
+	request := &delav1alpha1.Request{}
+	err := i.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, request)
+	if err == nil {
+		return request, true, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, false, err
+	}
+
+	request = &delav1alpha1.Request{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: delav1alpha1.RequestSpec{
+			IntentRef:        delav1alpha1.IntentReference{Namespace: intent.Namespace, Name: intent.Name},
+			SecretObjectMeta: metav1.ObjectMeta{Name: name},
+		},
+	}
+
+	return request, false, nil
+}
+
+// injectNamePrefixLength is the maximum length of the readable part of injected Request names,
+// which keeps the name of the injected volume within the 63 character limit.
+const injectNamePrefixLength = 40
+
+// injectRequestName returns the name of the Request and Secret copy created for an Intent.
+// The name ends with a hash of the Intent reference, as joining the namespace and name is ambiguous when they contain dashes.
+func injectRequestName(intentNN types.NamespacedName) string {
+	prefix := intentNN.Namespace + "-" + intentNN.Name
+	if len(prefix) > injectNamePrefixLength {
+		prefix = strings.TrimRight(prefix[:injectNamePrefixLength], "-.")
+	}
+	hash := sha256.Sum256([]byte(intentNN.String()))
+
+	return prefix + "-" + hex.EncodeToString(hash[:])[:10]
+}
+
+// injectContainers returns the indexes of the containers to inject the Secret into.
+func injectContainers(pod *corev1.Pod) ([]int, error) {
+	indexes := []int{}
+	names, ok := pod.Annotations[delav1alpha1.InjectContainersAnnotation]
+	if !ok {
+		for i := range pod.Spec.Containers {
+			indexes = append(indexes, i)
+		}
+		return indexes, nil
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for i, container := range pod.Spec.Containers {
+			if container.Name == name {
+				indexes = append(indexes, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Container %q in annotation %s does not exist", name, delav1alpha1.InjectContainersAnnotation)
+		}
+	}
+
+	return indexes, nil
+}
+
+// injectVolume adds a volume for the Secret to the Pod and mounts it in the containers.
+func injectVolume(pod *corev1.Pod, containers []int, secretName string, mountPath string) {
+	volumeName := "dela-" + secretName
+	hasVolume := false
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == volumeName {
+			hasVolume = true
+		}
+	}
+	if !hasVolume {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+			},
+		})
+	}
+
+	for _, i := range containers {
+		container := &pod.Spec.Containers[i]
+		hasMount := false
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == volumeName {
+				hasMount = true
+			}
+		}
+		if !hasMount {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+}
+
+// injectEnvFrom exposes the Secret keys as environment variables in the containers.
+func injectEnvFrom(pod *corev1.Pod, containers []int, secretName string) {
+	for _, i := range containers {
+		container := &pod.Spec.Containers[i]
+		hasEnvFrom := false
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == secretName {
+				hasEnvFrom = true
+			}
+		}
+		if !hasEnvFrom {
+			container.EnvFrom = append(container.EnvFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}},
+			})
+		}
+	}
+}
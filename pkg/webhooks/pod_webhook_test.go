@@ -0,0 +1,185 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+var _ = Describe("Pod Webhook", func() {
+	const timeout = time.Second * 30
+	const interval = time.Second * 1
+
+	ctx := context.TODO()
+	source := SetupTestNamespace(ctx)
+	dest := SetupTestNamespace(ctx)
+
+	// Creates the Secret and Intent, and waits for the Intent to be ready.
+	createReadyIntent := func(intent *delav1alpha1.Intent) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: intent.Spec.SecretName, Namespace: intent.Namespace},
+			Data:       map[string][]byte{"foo": []byte("bar")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+		Eventually(func() delav1alpha1.IntentState {
+			_ = k8sClient.Get(ctx, types.NamespacedName{Name: intent.Name, Namespace: intent.Namespace}, intent)
+			return intent.Status.State
+		}, timeout, interval).Should(Equal(delav1alpha1.IntentStateReady))
+	}
+
+	var injector *PodInjector
+	BeforeEach(func() {
+		injector = &PodInjector{Client: k8sClient}
+		Expect(injector.InjectDecoder(decoder)).Should(Succeed())
+	})
+
+	It("Ignores Pods without annotation", func() {
+		pod := basePod(dest, nil)
+		resp := injector.Handle(ctx, admissionRequest(pod))
+		Expect(resp.Allowed).Should(BeTrue())
+		Expect(resp.Patches).Should(BeEmpty())
+	})
+
+	It("Injects the Secret as a volume and creates a Request", func() {
+		intent := baseIntent(source)
+		createReadyIntent(intent)
+
+		pod := basePod(dest, map[string]string{
+			delav1alpha1.InjectIntentAnnotation: source.Name + "/" + intent.Name,
+		})
+		resp := injector.Handle(ctx, admissionRequest(pod))
+		Expect(resp.Allowed).Should(BeTrue())
+		Expect(patchPaths(resp)).Should(ContainElement("/spec/volumes"))
+		Expect(patchPaths(resp)).Should(ContainElement("/spec/containers/0/volumeMounts"))
+
+		request := &delav1alpha1.Request{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: dest.Name, Name: injectRequestName(types.NamespacedName{Namespace: source.Name, Name: intent.Name})}, request)).Should(Succeed())
+		Expect(request.Spec.IntentRef.Name).Should(Equal(intent.Name))
+	})
+
+	It("Injects the Secret as envFrom into the annotated containers", func() {
+		intent := baseIntent(source)
+		createReadyIntent(intent)
+
+		pod := basePod(dest, map[string]string{
+			delav1alpha1.InjectIntentAnnotation:     source.Name + "/" + intent.Name,
+			delav1alpha1.InjectModeAnnotation:       string(delav1alpha1.InjectionModeEnvFrom),
+			delav1alpha1.InjectContainersAnnotation: "sidecar",
+		})
+		resp := injector.Handle(ctx, admissionRequest(pod))
+		Expect(resp.Allowed).Should(BeTrue())
+		Expect(patchPaths(resp)).Should(ConsistOf("/spec/containers/1/envFrom"))
+	})
+
+	It("Denies Pods for Intents that do not exist", func() {
+		pod := basePod(dest, map[string]string{
+			delav1alpha1.InjectIntentAnnotation: source.Name + "/missing",
+		})
+		resp := injector.Handle(ctx, admissionRequest(pod))
+		Expect(resp.Allowed).Should(BeFalse())
+	})
+
+	It("Denies Pods for Intents that are not ready", func() {
+		intent := baseIntent(source)
+		Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+
+		pod := basePod(dest, map[string]string{
+			delav1alpha1.InjectIntentAnnotation: source.Name + "/" + intent.Name,
+		})
+		resp := injector.Handle(ctx, admissionRequest(pod))
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Reason).Should(ContainSubstring("is not ready"))
+	})
+
+	It("Does not create a Request for dry run requests", func() {
+		intent := baseIntent(source)
+		createReadyIntent(intent)
+
+		pod := basePod(dest, map[string]string{
+			delav1alpha1.InjectIntentAnnotation: source.Name + "/" + intent.Name,
+		})
+		req := admissionRequest(pod)
+		dryRun := true
+		req.DryRun = &dryRun
+		resp := injector.Handle(ctx, req)
+		Expect(resp.Allowed).Should(BeTrue())
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: dest.Name, Name: injectRequestName(types.NamespacedName{Namespace: source.Name, Name: intent.Name})}, &delav1alpha1.Request{})
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+	})
+
+	It("Denies Pods in namespaces that are not whitelisted", func() {
+		intent := baseIntent(source)
+		intent.Spec.NamespaceWhitelist = []string{dest.Name + "-extra"}
+		createReadyIntent(intent)
+
+		pod := basePod(dest, map[string]string{
+			delav1alpha1.InjectIntentAnnotation: source.Name + "/" + intent.Name,
+		})
+		resp := injector.Handle(ctx, admissionRequest(pod))
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Reason).Should(ContainSubstring("does not allow"))
+	})
+})
+
+var _ = Describe("Injected Request names", func() {
+	It("Does not collide for Intents with dashes", func() {
+		a := injectRequestName(types.NamespacedName{Namespace: "a-b", Name: "c"})
+		b := injectRequestName(types.NamespacedName{Namespace: "a", Name: "b-c"})
+		Expect(a).ShouldNot(Equal(b))
+		Expect(a).Should(HavePrefix("a-b-c-"))
+	})
+
+	It("Keeps the injected volume name short", func() {
+		name := injectRequestName(types.NamespacedName{Namespace: strings.Repeat("a", 63), Name: strings.Repeat("b", 63)})
+		Expect(len("dela-" + name)).Should(BeNumerically("<=", 63))
+	})
+})
+
+// Creates a base Intent for tests.
+func baseIntent(source *corev1.Namespace) *delav1alpha1.Intent {
+	return &delav1alpha1.Intent{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: source.Name},
+		Spec:       delav1alpha1.IntentSpec{SecretName: "main"},
+	}
+}
+
+// Creates a base Pod with two containers for tests.
+func basePod(dest *corev1.Namespace, annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "main",
+			Namespace:   dest.Name,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "main", Image: "main"},
+				{Name: "sidecar", Image: "sidecar"},
+			},
+		},
+	}
+}
+
+// Returns the paths of the top level fields changed by the patches.
+func patchPaths(resp admission.Response) []string {
+	paths := []string{}
+	for _, patch := range resp.Patches {
+		parts := strings.Split(patch.Path, "/")
+		if len(parts) > 5 {
+			parts = parts[:5]
+		}
+		paths = append(paths, strings.Join(parts, "/"))
+	}
+	return paths
+}
@@ -0,0 +1,90 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+	"github.com/phillebaba/dela/pkg/controllers"
+)
+
+// +kubebuilder:webhook:path=/validate-dela-phillebaba-io-v1alpha1-request,mutating=false,failurePolicy=fail,groups=dela.phillebaba.io,resources=requests,verbs=create;update,versions=v1alpha1,name=vrequest.dela.phillebaba.io
+
+// RequestValidator validates Requests before they are admitted
+type RequestValidator struct {
+	Client  client.Client
+	decoder *admission.Decoder
+}
+
+func (v *RequestValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	request := &delav1alpha1.Request{}
+	if err := v.decoder.Decode(req, request); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if request.Namespace == "" {
+		request.Namespace = req.Namespace
+	}
+
+	// Only changes to the spec are validated, so that finalizers can always be removed
+	if req.Operation == admissionv1beta1.Update {
+		if request.DeletionTimestamp != nil {
+			return admission.Allowed("")
+		}
+		old := &delav1alpha1.Request{}
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(old.Spec, request.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	violation, err := controllers.RequestPolicyViolation(ctx, v.Client, request)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if violation != "" {
+		return admission.Denied(violation)
+	}
+
+	conflict, err := controllers.ClaimConflict(ctx, v.Client, request)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if conflict != "" {
+		return admission.Denied(conflict)
+	}
+
+	// Intent is allowed to be missing as Requests can be created before Intents
+	intentNN := types.NamespacedName{Name: request.Spec.IntentRef.Name, Namespace: request.Spec.IntentRef.Namespace}
+	intent := &delav1alpha1.Intent{}
+	if err := v.Client.Get(ctx, intentNN, intent); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		intent = nil
+	}
+
+	quotaMsg, err := controllers.QuotaExceeded(ctx, v.Client, request, intent)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if quotaMsg != "" {
+		return admission.Denied(quotaMsg)
+	}
+
+	return admission.Allowed("")
+}
+
+// InjectDecoder injects the decoder into the RequestValidator
+func (v *RequestValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
@@ -0,0 +1,210 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	delav1alpha1 "github.com/phillebaba/dela/pkg/api/v1alpha1"
+)
+
+var _ = Describe("Request Webhook", func() {
+	const timeout = time.Second * 30
+	const interval = time.Second * 1
+
+	ctx := context.TODO()
+	source := SetupTestNamespace(ctx)
+	dest := SetupTestNamespace(ctx)
+
+	var validator *RequestValidator
+	BeforeEach(func() {
+		validator = &RequestValidator{Client: k8sClient}
+		Expect(validator.InjectDecoder(decoder)).Should(Succeed())
+	})
+
+	It("Allows Requests for missing Intents", func() {
+		request := baseRequest(source, dest, "main")
+		Expect(validator.Handle(ctx, admissionRequest(request)).Allowed).Should(BeTrue())
+	})
+
+	It("Denies Requests exceeding the Intent max requests", func() {
+		maxRequests := int32(1)
+		intent := &delav1alpha1.Intent{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: source.Name},
+			Spec: delav1alpha1.IntentSpec{
+				SecretName:  "main",
+				MaxRequests: &maxRequests,
+			},
+		}
+		request := baseRequest(source, dest, "main")
+
+		By("Creating an Intent and a Request")
+		Expect(k8sClient.Create(ctx, intent)).Should(Succeed())
+		Expect(validator.Handle(ctx, admissionRequest(request)).Allowed).Should(BeTrue())
+		Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+
+		By("Validating a second Request")
+		second := baseRequest(source, dest, "second")
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(second)).Allowed
+		}, timeout, interval).Should(BeFalse())
+	})
+
+	It("Denies Requests exceeding a RequestQuota", func() {
+		quota := &delav1alpha1.RequestQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: dest.Name},
+			Spec: delav1alpha1.RequestQuotaSpec{
+				Namespaces:  []string{dest.Name},
+				MaxRequests: 1,
+			},
+		}
+		request := baseRequest(source, dest, "main")
+
+		By("Creating a RequestQuota and a Request")
+		Expect(k8sClient.Create(ctx, quota)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+
+		By("Validating a second Request")
+		second := baseRequest(source, dest, "second")
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(second)).Allowed
+		}, timeout, interval).Should(BeFalse())
+
+		By("Deleting the RequestQuota")
+		Expect(k8sClient.Delete(ctx, quota)).Should(Succeed())
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(second)).Allowed
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("Denies Requests for a Secret claimed by another Request", func() {
+		request := baseRequest(source, dest, "main")
+		second := baseRequest(source, dest, "second")
+		second.Spec.SecretObjectMeta.Name = request.Spec.SecretObjectMeta.Name
+
+		By("Creating a Request")
+		Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+
+		By("Validating a second Request for the same Secret")
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(second)).Allowed
+		}, timeout, interval).Should(BeFalse())
+		Expect(k8sClient.Delete(ctx, request)).Should(Succeed())
+	})
+
+	It("Denies updates to a Secret claimed by another Request", func() {
+		request := baseRequest(source, dest, "main")
+		second := baseRequest(source, dest, "second")
+
+		By("Creating two Requests")
+		Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, second)).Should(Succeed())
+
+		By("Validating a change of the metadata of the second Request")
+		updated := second.DeepCopy()
+		updated.Finalizers = []string{delav1alpha1.RequestOrphanFinalizer}
+		Expect(validator.Handle(ctx, updateAdmissionRequest(second, updated)).Allowed).Should(BeTrue())
+
+		By("Validating a change of the Secret name of the second Request to the Secret of the first")
+		updated.Spec.SecretObjectMeta.Name = request.Spec.SecretObjectMeta.Name
+		Eventually(func() bool {
+			return validator.Handle(ctx, updateAdmissionRequest(second, updated)).Allowed
+		}, timeout, interval).Should(BeFalse())
+		Expect(k8sClient.Delete(ctx, request)).Should(Succeed())
+		Expect(k8sClient.Delete(ctx, second)).Should(Succeed())
+	})
+
+	It("Denies Requests for a versioned copy of another Request", func() {
+		request := baseRequest(source, dest, "main")
+		request.Spec.Versioning = &delav1alpha1.Versioning{}
+		second := baseRequest(source, dest, "second")
+		second.Spec.SecretObjectMeta.Name = request.Spec.SecretObjectMeta.Name + "-0123456789"
+
+		By("Creating a Request with versioning")
+		Expect(k8sClient.Create(ctx, request)).Should(Succeed())
+
+		By("Validating a second Request for a versioned copy name")
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(second)).Allowed
+		}, timeout, interval).Should(BeFalse())
+		Expect(k8sClient.Delete(ctx, request)).Should(Succeed())
+	})
+
+	It("Denies Requests denied by a SharePolicy", func() {
+		policy := &delav1alpha1.SharePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: dest.Name},
+			Spec: delav1alpha1.SharePolicySpec{
+				Deny: []delav1alpha1.SharePolicyRule{{
+					SourceNamespaces:      []string{source.Name},
+					DestinationNamespaces: []string{dest.Name},
+				}},
+			},
+		}
+		request := baseRequest(source, dest, "main")
+
+		By("Creating a SharePolicy")
+		Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+		Eventually(func() bool {
+			return validator.Handle(ctx, admissionRequest(request)).Allowed
+		}, timeout, interval).Should(BeFalse())
+		Expect(k8sClient.Delete(ctx, policy)).Should(Succeed())
+	})
+})
+
+// Creates a base Request for tests.
+func baseRequest(source *corev1.Namespace, dest *corev1.Namespace, name string) *delav1alpha1.Request {
+	return &delav1alpha1.Request{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: dest.Name,
+		},
+		Spec: delav1alpha1.RequestSpec{
+			IntentRef: delav1alpha1.IntentReference{
+				Name:      "main",
+				Namespace: source.Name,
+			},
+			SecretObjectMeta: metav1.ObjectMeta{
+				Name: name + "-copy",
+			},
+		},
+	}
+}
+
+// Wraps an object in a create admission request.
+func admissionRequest(obj runtime.Object) admission.Request {
+	raw, err := json.Marshal(obj)
+	Expect(err).NotTo(HaveOccurred())
+	accessor, err := meta.Accessor(obj)
+	Expect(err).NotTo(HaveOccurred())
+
+	return admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			UID:       types.UID(randStringRunes(8)),
+			Operation: admissionv1beta1.Create,
+			Name:      accessor.GetName(),
+			Namespace: accessor.GetNamespace(),
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+// updateAdmissionRequest returns an admission request updating the old object to the new object.
+func updateAdmissionRequest(old, obj runtime.Object) admission.Request {
+	req := admissionRequest(obj)
+	raw, err := json.Marshal(old)
+	Expect(err).NotTo(HaveOccurred())
+	req.Operation = admissionv1beta1.Update
+	req.OldObject = runtime.RawExtension{Raw: raw}
+
+	return req
+}